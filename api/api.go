@@ -44,19 +44,36 @@ type (
 //sys	SQLExecute(statementHandle SQLHSTMT) (ret SQLRETURN) = odbc32.SQLExecute
 //sys	SQLFetch(statementHandle SQLHSTMT) (ret SQLRETURN) = odbc32.SQLFetch
 //sys	SQLFreeHandle(handleType SQLSMALLINT, handle SQLHANDLE) (ret SQLRETURN) = odbc32.SQLFreeHandle
+//sys	SQLFreeStmt(statementHandle SQLHSTMT, option SQLUSMALLINT) (ret SQLRETURN) = odbc32.SQLFreeStmt
 //sys	SQLGetData(statementHandle SQLHSTMT, colOrParamNum SQLUSMALLINT, targetType SQLSMALLINT, targetValuePtr SQLPOINTER, bufferLength SQLLEN, vallen *SQLLEN) (ret SQLRETURN) = odbc32.SQLGetData
 //sys	SQLGetDiagRec(handleType SQLSMALLINT, handle SQLHANDLE, recNumber SQLSMALLINT, sqlState *SQLWCHAR, nativeErrorPtr *SQLINTEGER, messageText *SQLWCHAR, bufferLength SQLSMALLINT, textLengthPtr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLGetDiagRecW
+//sys	SQLGetDiagField(handleType SQLSMALLINT, handle SQLHANDLE, recNumber SQLSMALLINT, diagIdentifier SQLSMALLINT, diagInfoPtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLGetDiagFieldW
 //sys	SQLNumParams(statementHandle SQLHSTMT, parameterCountPtr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLNumParams
 //sys	SQLNumResultCols(statementHandle SQLHSTMT, columnCountPtr *SQLSMALLINT)  (ret SQLRETURN) = odbc32.SQLNumResultCols
 //sys	SQLPrepare(statementHandle SQLHSTMT, statementText *SQLWCHAR, textLength SQLINTEGER) (ret SQLRETURN) = odbc32.SQLPrepareW
 //sys	SQLRowCount(statementHandle SQLHSTMT, rowCountPtr *SQLLEN) (ret SQLRETURN) = odbc32.SQLRowCount
 //sys	SQLSetEnvAttr(environmentHandle SQLHENV, attribute SQLINTEGER, valuePtr SQLPOINTER, stringLength SQLINTEGER) (ret SQLRETURN) = odbc32.SQLSetEnvAttr
+//sys	SQLGetConnectAttr(connectionHandle SQLHDBC, attribute SQLINTEGER, valuePtr SQLPOINTER, bufferLength SQLINTEGER, stringLengthPtr *SQLINTEGER) (ret SQLRETURN) = odbc32.SQLGetConnectAttrW
 //sys	SQLSetConnectAttr(connectionHandle SQLHDBC, attribute SQLINTEGER, valuePtr SQLPOINTER, stringLength SQLINTEGER) (ret SQLRETURN) = odbc32.SQLSetConnectAttrW
 //sys	SQLSetStmtAttr(statementHandle SQLHSTMT, attribute SQLINTEGER, valuePtr SQLPOINTER, stringLength SQLINTEGER) (ret SQLRETURN) = odbc32.SQLSetStmtAttrW
+//sys	SQLGetStmtAttr(statementHandle SQLHSTMT, attribute SQLINTEGER, valuePtr SQLPOINTER, bufferLength SQLINTEGER, stringLengthPtr *SQLINTEGER) (ret SQLRETURN) = odbc32.SQLGetStmtAttrW
 //sys	SQLExecDirect(statementHandle SQLHSTMT, statementText *SQLWCHAR, textLength SQLINTEGER) (ret SQLRETURN) = odbc32.SQLExecDirectW
 //sys	SQLColAttribute(statementHandle SQLHSTMT, columnNumber SQLUSMALLINT, fieldIdentifier SQLUSMALLINT, characterAttributePtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT, numericAttributePtr *SQLLEN) (ret SQLRETURN) = odbc32.SQLColAttribute
-//sys	SQLGetInfo(connectionHandle SQLHDBC, infoType SQLUSMALLINT, infoValuePtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLGetInfo
+//sys	SQLGetInfo(connectionHandle SQLHDBC, infoType SQLUSMALLINT, infoValuePtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLGetInfoW
 //sys	SQLCancel(statementHandle SQLHSTMT) (ret SQLRETURN) = odbc32.SQLCancel
 //sys	SQLMoreResults(statementHandle SQLHSTMT) (ret SQLRETURN) = odbc32.SQLMoreResults
 //sys	SQLBulkOperations(statementHandle SQLHSTMT, operation SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLBulkOperations
 //sys	SQLFetchScroll(statementHandle SQLHSTMT, fetchOrientation SQLSMALLINT, fetchOffset SQLLEN) (ret SQLRETURN) = odbc32.SQLFetchScroll
+//sys	SQLParamData(statementHandle SQLHSTMT, valuePtrPtr *SQLPOINTER) (ret SQLRETURN) = odbc32.SQLParamData
+//sys	SQLPutData(statementHandle SQLHSTMT, dataPtr SQLPOINTER, strLen_or_Ind SQLLEN) (ret SQLRETURN) = odbc32.SQLPutData
+//sys	SQLTables(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, tableType *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLTablesW
+//sys	SQLColumns(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, columnName *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLColumnsW
+//sys	SQLPrimaryKeys(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLPrimaryKeysW
+//sys	SQLForeignKeys(statementHandle SQLHSTMT, pkCatalogName *SQLWCHAR, nameLength1 SQLSMALLINT, pkSchemaName *SQLWCHAR, nameLength2 SQLSMALLINT, pkTableName *SQLWCHAR, nameLength3 SQLSMALLINT, fkCatalogName *SQLWCHAR, nameLength4 SQLSMALLINT, fkSchemaName *SQLWCHAR, nameLength5 SQLSMALLINT, fkTableName *SQLWCHAR, nameLength6 SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLForeignKeysW
+//sys	SQLStatistics(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, unique SQLUSMALLINT, reserved SQLUSMALLINT) (ret SQLRETURN) = odbc32.SQLStatisticsW
+//sys	SQLSpecialColumns(statementHandle SQLHSTMT, identifierType SQLUSMALLINT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, scope SQLUSMALLINT, nullable SQLUSMALLINT) (ret SQLRETURN) = odbc32.SQLSpecialColumnsW
+//sys	SQLProcedures(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, procName *SQLWCHAR, nameLength3 SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLProceduresW
+//sys	SQLProcedureColumns(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, procName *SQLWCHAR, nameLength3 SQLSMALLINT, columnName *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLProcedureColumnsW
+//sys	SQLGetFunctions(connectionHandle SQLHDBC, functionId SQLUSMALLINT, supportedPtr *SQLUSMALLINT) (ret SQLRETURN) = odbc32.SQLGetFunctions
+//sys	SQLDrivers(environmentHandle SQLHENV, direction SQLUSMALLINT, driverDescription *SQLWCHAR, bufferLength1 SQLSMALLINT, descriptionLengthPtr *SQLSMALLINT, driverAttributes *SQLWCHAR, bufferLength2 SQLSMALLINT, attributesLengthPtr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLDriversW
+//sys	SQLDataSources(environmentHandle SQLHENV, direction SQLUSMALLINT, serverName *SQLWCHAR, bufferLength1 SQLSMALLINT, nameLength1Ptr *SQLSMALLINT, description *SQLWCHAR, bufferLength2 SQLSMALLINT, nameLength2Ptr *SQLSMALLINT) (ret SQLRETURN) = odbc32.SQLDataSourcesW
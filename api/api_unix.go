@@ -67,10 +67,13 @@ const (
 	SQL_NULL_HDBC          = uintptr(C.SQL_NULL_HDBC)
 	SQL_NULL_HSTMT         = uintptr(C.SQL_NULL_HSTMT)
 
-	SQL_PARAM_INPUT = C.SQL_PARAM_INPUT
+	SQL_PARAM_INPUT        = C.SQL_PARAM_INPUT
+	SQL_PARAM_INPUT_OUTPUT = C.SQL_PARAM_INPUT_OUTPUT
+	SQL_PARAM_OUTPUT       = C.SQL_PARAM_OUTPUT
 
-	SQL_NULL_DATA    = C.SQL_NULL_DATA
-	SQL_DATA_AT_EXEC = C.SQL_DATA_AT_EXEC
+	SQL_NULL_DATA               = C.SQL_NULL_DATA
+	SQL_DATA_AT_EXEC            = C.SQL_DATA_AT_EXEC
+	SQL_LEN_DATA_AT_EXEC_OFFSET = C.SQL_LEN_DATA_AT_EXEC_OFFSET
 
 	SQL_UNKNOWN_TYPE    = C.SQL_UNKNOWN_TYPE
 	SQL_CHAR            = C.SQL_CHAR
@@ -114,6 +117,8 @@ const (
 	SQL_C_NUMERIC        = C.SQL_C_NUMERIC
 	SQL_C_DATE           = C.SQL_C_DATE
 	SQL_C_TIME           = C.SQL_C_TIME
+	SQL_C_TYPE_DATE      = C.SQL_C_TYPE_DATE
+	SQL_C_TYPE_TIME      = C.SQL_C_TYPE_TIME
 	SQL_C_TYPE_TIMESTAMP = C.SQL_C_TYPE_TIMESTAMP
 	SQL_C_TIMESTAMP      = C.SQL_C_TIMESTAMP
 	SQL_C_BINARY         = C.SQL_C_BINARY
@@ -130,6 +135,11 @@ const (
 	SQL_COMMIT   = C.SQL_COMMIT
 	SQL_ROLLBACK = C.SQL_ROLLBACK
 
+	//for SQLFreeStmt
+	SQL_CLOSE        = C.SQL_CLOSE
+	SQL_UNBIND       = C.SQL_UNBIND
+	SQL_RESET_PARAMS = C.SQL_RESET_PARAMS
+
 	SQL_AUTOCOMMIT         = C.SQL_AUTOCOMMIT
 	SQL_ATTR_AUTOCOMMIT    = C.SQL_ATTR_AUTOCOMMIT
 	SQL_AUTOCOMMIT_OFF     = C.SQL_AUTOCOMMIT_OFF
@@ -138,6 +148,25 @@ const (
 
 	SQL_IS_UINTEGER = C.SQL_IS_UINTEGER
 
+	//Transaction isolation and access mode
+	SQL_ATTR_TXN_ISOLATION    = C.SQL_ATTR_TXN_ISOLATION
+	SQL_TXN_READ_UNCOMMITTED  = C.SQL_TXN_READ_UNCOMMITTED
+	SQL_TXN_READ_COMMITTED    = C.SQL_TXN_READ_COMMITTED
+	SQL_TXN_REPEATABLE_READ   = C.SQL_TXN_REPEATABLE_READ
+	SQL_TXN_SERIALIZABLE      = C.SQL_TXN_SERIALIZABLE
+	SQL_TXN_ISOLATION_OPTION  = C.SQL_TXN_ISOLATION_OPTION
+	SQL_DEFAULT_TXN_ISOLATION = C.SQL_DEFAULT_TXN_ISOLATION
+	SQL_ATTR_ACCESS_MODE      = C.SQL_ATTR_ACCESS_MODE
+	SQL_MODE_READ_WRITE       = C.SQL_MODE_READ_WRITE
+	SQL_MODE_READ_ONLY        = C.SQL_MODE_READ_ONLY
+
+	SQL_ATTR_LOGIN_TIMEOUT      = C.SQL_ATTR_LOGIN_TIMEOUT
+	SQL_ATTR_CONNECTION_TIMEOUT = C.SQL_ATTR_CONNECTION_TIMEOUT
+	SQL_ATTR_CURRENT_CATALOG    = C.SQL_ATTR_CURRENT_CATALOG
+	SQL_ATTR_PACKET_SIZE        = C.SQL_ATTR_PACKET_SIZE
+	SQL_ATTR_CONNECTION_DEAD    = C.SQL_ATTR_CONNECTION_DEAD
+	SQL_CD_TRUE                 = C.SQL_CD_TRUE
+
 	//Connection pooling
 	SQL_ATTR_CONNECTION_POOLING = C.SQL_ATTR_CONNECTION_POOLING
 	SQL_ATTR_CP_MATCH           = C.SQL_ATTR_CP_MATCH
@@ -178,6 +207,85 @@ const (
 	SQL_DESC_CONCISE_TYPE = C.SQL_DESC_CONCISE_TYPE
 	SQL_NEED_DATA         = C.SQL_NEED_DATA
 	SQL_DESC_LENGTH       = C.SQL_DESC_LENGTH
+	SQL_DESC_TYPE_NAME    = C.SQL_DESC_TYPE_NAME
+	SQL_DESC_UNSIGNED     = C.SQL_DESC_UNSIGNED
+
+	SQL_NO_NULLS         = C.SQL_NO_NULLS
+	SQL_NULLABLE         = C.SQL_NULLABLE
+	SQL_NULLABLE_UNKNOWN = C.SQL_NULLABLE_UNKNOWN
+
+	SQL_INDEX_UNIQUE = C.SQL_INDEX_UNIQUE
+	SQL_INDEX_ALL    = C.SQL_INDEX_ALL
+	SQL_QUICK        = C.SQL_QUICK
+	SQL_ENSURE       = C.SQL_ENSURE
+
+	SQL_BEST_ROWID        = C.SQL_BEST_ROWID
+	SQL_ROWVER            = C.SQL_ROWVER
+	SQL_SCOPE_CURROW      = C.SQL_SCOPE_CURROW
+	SQL_SCOPE_TRANSACTION = C.SQL_SCOPE_TRANSACTION
+	SQL_SCOPE_SESSION     = C.SQL_SCOPE_SESSION
+
+	SQL_DATA_SOURCE_NAME           = C.SQL_DATA_SOURCE_NAME
+	SQL_MAX_CONCURRENT_ACTIVITIES  = C.SQL_MAX_CONCURRENT_ACTIVITIES
+	SQL_ODBC_VER                   = C.SQL_ODBC_VER
+	SQL_SEARCH_PATTERN_ESCAPE      = C.SQL_SEARCH_PATTERN_ESCAPE
+	SQL_DBMS_NAME                  = C.SQL_DBMS_NAME
+	SQL_DATA_SOURCE_READ_ONLY      = C.SQL_DATA_SOURCE_READ_ONLY
+	SQL_IDENTIFIER_QUOTE_CHAR      = C.SQL_IDENTIFIER_QUOTE_CHAR
+	SQL_MAX_COLUMN_NAME_LEN        = C.SQL_MAX_COLUMN_NAME_LEN
+	SQL_MAX_SCHEMA_NAME_LEN        = C.SQL_MAX_SCHEMA_NAME_LEN
+	SQL_MAX_CATALOG_NAME_LEN       = C.SQL_MAX_CATALOG_NAME_LEN
+	SQL_MAX_TABLE_NAME_LEN         = C.SQL_MAX_TABLE_NAME_LEN
+	SQL_SCROLL_OPTIONS             = C.SQL_SCROLL_OPTIONS
+	SQL_TXN_CAPABLE                = C.SQL_TXN_CAPABLE
+	SQL_USER_NAME                  = C.SQL_USER_NAME
+	SQL_GETDATA_EXTENSIONS         = C.SQL_GETDATA_EXTENSIONS
+	SQL_BATCH_ROW_COUNT            = C.SQL_BATCH_ROW_COUNT
+	SQL_BATCH_SUPPORT              = C.SQL_BATCH_SUPPORT
+	SQL_DYNAMIC_CURSOR_ATTRIBUTES1 = C.SQL_DYNAMIC_CURSOR_ATTRIBUTES1
+	SQL_KEYSET_CURSOR_ATTRIBUTES1  = C.SQL_KEYSET_CURSOR_ATTRIBUTES1
+	SQL_PARAM_ARRAY_ROW_COUNTS     = C.SQL_PARAM_ARRAY_ROW_COUNTS
+	SQL_STATIC_CURSOR_ATTRIBUTES1  = C.SQL_STATIC_CURSOR_ATTRIBUTES1
+	SQL_CURSOR_COMMIT_BEHAVIOR     = C.SQL_CURSOR_COMMIT_BEHAVIOR
+	SQL_CURSOR_ROLLBACK_BEHAVIOR   = C.SQL_CURSOR_ROLLBACK_BEHAVIOR
+
+	SQL_CB_DELETE   = C.SQL_CB_DELETE
+	SQL_CB_CLOSE    = C.SQL_CB_CLOSE
+	SQL_CB_PRESERVE = C.SQL_CB_PRESERVE
+
+	SQL_SO_FORWARD_ONLY       = C.SQL_SO_FORWARD_ONLY
+	SQL_SO_KEYSET_DRIVEN      = C.SQL_SO_KEYSET_DRIVEN
+	SQL_SO_DYNAMIC            = C.SQL_SO_DYNAMIC
+	SQL_SO_MIXED              = C.SQL_SO_MIXED
+	SQL_SO_STATIC             = C.SQL_SO_STATIC
+	SQL_BS_SELECT_EXPLICIT    = C.SQL_BS_SELECT_EXPLICIT
+	SQL_BS_ROW_COUNT_EXPLICIT = C.SQL_BS_ROW_COUNT_EXPLICIT
+	SQL_BS_SELECT_PROC        = C.SQL_BS_SELECT_PROC
+	SQL_BS_ROW_COUNT_PROC     = C.SQL_BS_ROW_COUNT_PROC
+	SQL_GD_ANY_COLUMN         = C.SQL_GD_ANY_COLUMN
+	SQL_GD_ANY_ORDER          = C.SQL_GD_ANY_ORDER
+	SQL_GD_BLOCK              = C.SQL_GD_BLOCK
+	SQL_GD_BOUND              = C.SQL_GD_BOUND
+	SQL_CA1_BULK_ADD          = C.SQL_CA1_BULK_ADD
+
+	SQL_API_SQLCANCEL           = C.SQL_API_SQLCANCEL
+	SQL_API_SQLBULKOPERATIONS   = C.SQL_API_SQLBULKOPERATIONS
+	SQL_API_SQLCOLUMNS          = C.SQL_API_SQLCOLUMNS
+	SQL_API_SQLGETDATA          = C.SQL_API_SQLGETDATA
+	SQL_API_SQLPARAMDATA        = C.SQL_API_SQLPARAMDATA
+	SQL_API_SQLPUTDATA          = C.SQL_API_SQLPUTDATA
+	SQL_API_SQLSPECIALCOLUMNS   = C.SQL_API_SQLSPECIALCOLUMNS
+	SQL_API_SQLSTATISTICS       = C.SQL_API_SQLSTATISTICS
+	SQL_API_SQLTABLES           = C.SQL_API_SQLTABLES
+	SQL_API_SQLDESCRIBEPARAM    = C.SQL_API_SQLDESCRIBEPARAM
+	SQL_API_SQLFOREIGNKEYS      = C.SQL_API_SQLFOREIGNKEYS
+	SQL_API_SQLMORERESULTS      = C.SQL_API_SQLMORERESULTS
+	SQL_API_SQLNUMPARAMS        = C.SQL_API_SQLNUMPARAMS
+	SQL_API_SQLPRIMARYKEYS      = C.SQL_API_SQLPRIMARYKEYS
+	SQL_API_SQLPROCEDURECOLUMNS = C.SQL_API_SQLPROCEDURECOLUMNS
+	SQL_API_SQLPROCEDURES       = C.SQL_API_SQLPROCEDURES
+	SQL_API_SQLSETPOS           = C.SQL_API_SQLSETPOS
+	SQL_API_SQLFETCHSCROLL      = C.SQL_API_SQLFETCHSCROLL
 
 	SQL_ADD = C.SQL_ADD
 
@@ -185,9 +293,50 @@ const (
 	SQL_FETCH_FIRST = C.SQL_FETCH_FIRST
 	SQL_FETCH_LAST  = C.SQL_FETCH_LAST
 
+	SQL_FETCH_FIRST_USER   = C.SQL_FETCH_FIRST_USER
+	SQL_FETCH_FIRST_SYSTEM = C.SQL_FETCH_FIRST_SYSTEM
+
 	SQL_BIND_BY_COLUMN      = uintptr(C.SQL_BIND_BY_COLUMN)
 	SQL_FETCH_NEXT          = C.SQL_FETCH_NEXT
 	SQL_CURSOR_FORWARD_ONLY = uintptr(C.SQL_CURSOR_FORWARD_ONLY)
+
+	SQL_ATTR_PARAM_BIND_TYPE      = C.SQL_ATTR_PARAM_BIND_TYPE
+	SQL_PARAM_BIND_BY_COLUMN      = uintptr(C.SQL_PARAM_BIND_BY_COLUMN)
+	SQL_ATTR_PARAMSET_SIZE        = C.SQL_ATTR_PARAMSET_SIZE
+	SQL_ATTR_PARAM_STATUS_PTR     = C.SQL_ATTR_PARAM_STATUS_PTR
+	SQL_ATTR_PARAMS_PROCESSED_PTR = C.SQL_ATTR_PARAMS_PROCESSED_PTR
+
+	SQL_PARAM_SUCCESS           = C.SQL_PARAM_SUCCESS
+	SQL_PARAM_SUCCESS_WITH_INFO = C.SQL_PARAM_SUCCESS_WITH_INFO
+	SQL_PARAM_ERROR             = C.SQL_PARAM_ERROR
+	SQL_PARAM_UNUSED            = C.SQL_PARAM_UNUSED
+	SQL_PARAM_DIAG_UNAVAILABLE  = C.SQL_PARAM_DIAG_UNAVAILABLE
+
+	SQL_ATTR_QUERY_TIMEOUT = C.SQL_ATTR_QUERY_TIMEOUT
+	SQL_ATTR_MAX_ROWS      = C.SQL_ATTR_MAX_ROWS
+	SQL_ATTR_NOSCAN        = C.SQL_ATTR_NOSCAN
+	SQL_NOSCAN_OFF         = uintptr(C.SQL_NOSCAN_OFF)
+	SQL_NOSCAN_ON          = uintptr(C.SQL_NOSCAN_ON)
+	SQL_CURSOR_STATIC      = uintptr(C.SQL_CURSOR_STATIC)
+	SQL_CONCUR_READ_ONLY   = uintptr(C.SQL_CONCUR_READ_ONLY)
+	SQL_CONCUR_VALUES      = uintptr(C.SQL_CONCUR_VALUES)
+	SQL_NONSCROLLABLE      = uintptr(C.SQL_NONSCROLLABLE)
+
+	SQL_FETCH_PRIOR             = C.SQL_FETCH_PRIOR
+	SQL_FETCH_ABSOLUTE          = C.SQL_FETCH_ABSOLUTE
+	SQL_FETCH_RELATIVE          = C.SQL_FETCH_RELATIVE
+	SQL_FETCH_BOOKMARK          = C.SQL_FETCH_BOOKMARK
+	SQL_ATTR_FETCH_BOOKMARK_PTR = C.SQL_ATTR_FETCH_BOOKMARK_PTR
+	SQL_UB_OFF                  = uintptr(C.SQL_UB_OFF)
+
+	SQL_DIAG_CURSOR_ROW_COUNT = C.SQL_DIAG_CURSOR_ROW_COUNT
+	SQL_DIAG_DYNAMIC_FUNCTION = C.SQL_DIAG_DYNAMIC_FUNCTION
+	SQL_DIAG_ROW_NUMBER       = C.SQL_DIAG_ROW_NUMBER
+	SQL_DIAG_COLUMN_NUMBER    = C.SQL_DIAG_COLUMN_NUMBER
+	SQL_DIAG_SERVER_NAME      = C.SQL_DIAG_SERVER_NAME
+	SQL_DIAG_CONNECTION_NAME  = C.SQL_DIAG_CONNECTION_NAME
+	SQL_DIAG_CLASS_ORIGIN     = C.SQL_DIAG_CLASS_ORIGIN
+	SQL_DIAG_SUBCLASS_ORIGIN  = C.SQL_DIAG_SUBCLASS_ORIGIN
 )
 
 type (
@@ -33,10 +33,13 @@ const (
 	SQL_NULL_HDBC          = 0
 	SQL_NULL_HSTMT         = 0
 
-	SQL_PARAM_INPUT = 1
+	SQL_PARAM_INPUT        = 1
+	SQL_PARAM_INPUT_OUTPUT = 2
+	SQL_PARAM_OUTPUT       = 4
 
-	SQL_NULL_DATA    = -1
-	SQL_DATA_AT_EXEC = -2
+	SQL_NULL_DATA               = -1
+	SQL_DATA_AT_EXEC            = -2
+	SQL_LEN_DATA_AT_EXEC_OFFSET = -100
 
 	SQL_UNKNOWN_TYPE    = 0
 	SQL_CHAR            = 1
@@ -78,6 +81,8 @@ const (
 	SQL_C_NUMERIC        = SQL_NUMERIC
 	SQL_C_DATE           = SQL_DATE
 	SQL_C_TIME           = SQL_TIME
+	SQL_C_TYPE_DATE      = SQL_TYPE_DATE
+	SQL_C_TYPE_TIME      = SQL_TYPE_TIME
 	SQL_C_TYPE_TIMESTAMP = SQL_TYPE_TIMESTAMP
 	SQL_C_TIMESTAMP      = SQL_TIMESTAMP
 	SQL_C_BINARY         = SQL_BINARY
@@ -93,6 +98,11 @@ const (
 	SQL_COMMIT   = 0
 	SQL_ROLLBACK = 1
 
+	//for SQLFreeStmt
+	SQL_CLOSE        = 0
+	SQL_UNBIND       = 2
+	SQL_RESET_PARAMS = 3
+
 	SQL_AUTOCOMMIT         = 102
 	SQL_ATTR_AUTOCOMMIT    = SQL_AUTOCOMMIT
 	SQL_AUTOCOMMIT_OFF     = 0
@@ -101,6 +111,25 @@ const (
 
 	SQL_IS_UINTEGER = -5
 
+	//Transaction isolation and access mode
+	SQL_ATTR_TXN_ISOLATION    = 108
+	SQL_TXN_READ_UNCOMMITTED  = 1
+	SQL_TXN_READ_COMMITTED    = 2
+	SQL_TXN_REPEATABLE_READ   = 4
+	SQL_TXN_SERIALIZABLE      = 8
+	SQL_TXN_ISOLATION_OPTION  = 72
+	SQL_DEFAULT_TXN_ISOLATION = 26
+	SQL_ATTR_ACCESS_MODE      = 101
+	SQL_MODE_READ_WRITE       = 0
+	SQL_MODE_READ_ONLY        = 1
+
+	SQL_ATTR_LOGIN_TIMEOUT      = 103
+	SQL_ATTR_CONNECTION_TIMEOUT = 113
+	SQL_ATTR_CURRENT_CATALOG    = 109
+	SQL_ATTR_PACKET_SIZE        = 112
+	SQL_ATTR_CONNECTION_DEAD    = 1209
+	SQL_CD_TRUE                 = 1
+
 	//Connection pooling
 	SQL_ATTR_CONNECTION_POOLING = 201
 	SQL_ATTR_CP_MATCH           = 202
@@ -140,10 +169,135 @@ const (
 	SQL_DESC_CONCISE_TYPE = 2
 	SQL_NEED_DATA         = 99
 	SQL_DESC_LENGTH       = 1003
+	SQL_DESC_TYPE_NAME    = 14
+	SQL_DESC_UNSIGNED     = 8
+
+	SQL_NO_NULLS         = 0
+	SQL_NULLABLE         = 1
+	SQL_NULLABLE_UNKNOWN = 2
+
+	SQL_INDEX_UNIQUE = 0
+	SQL_INDEX_ALL    = 1
+	SQL_QUICK        = 0
+	SQL_ENSURE       = 1
+
+	SQL_BEST_ROWID        = 1
+	SQL_ROWVER            = 2
+	SQL_SCOPE_CURROW      = 0
+	SQL_SCOPE_TRANSACTION = 1
+	SQL_SCOPE_SESSION     = 2
+
+	SQL_DATA_SOURCE_NAME           = 2
+	SQL_MAX_CONCURRENT_ACTIVITIES  = 1
+	SQL_ODBC_VER                   = 10
+	SQL_SEARCH_PATTERN_ESCAPE      = 14
+	SQL_DBMS_NAME                  = 17
+	SQL_DATA_SOURCE_READ_ONLY      = 25
+	SQL_IDENTIFIER_QUOTE_CHAR      = 29
+	SQL_MAX_COLUMN_NAME_LEN        = 30
+	SQL_MAX_SCHEMA_NAME_LEN        = 32
+	SQL_MAX_CATALOG_NAME_LEN       = 34
+	SQL_MAX_TABLE_NAME_LEN         = 35
+	SQL_SCROLL_OPTIONS             = 44
+	SQL_TXN_CAPABLE                = 46
+	SQL_USER_NAME                  = 47
+	SQL_GETDATA_EXTENSIONS         = 81
+	SQL_BATCH_ROW_COUNT            = 120
+	SQL_BATCH_SUPPORT              = 121
+	SQL_DYNAMIC_CURSOR_ATTRIBUTES1 = 144
+	SQL_KEYSET_CURSOR_ATTRIBUTES1  = 150
+	SQL_PARAM_ARRAY_ROW_COUNTS     = 153
+	SQL_STATIC_CURSOR_ATTRIBUTES1  = 167
+	SQL_CURSOR_COMMIT_BEHAVIOR     = 23
+	SQL_CURSOR_ROLLBACK_BEHAVIOR   = 24
+
+	SQL_CB_DELETE   = 0
+	SQL_CB_CLOSE    = 1
+	SQL_CB_PRESERVE = 2
+
+	SQL_SO_FORWARD_ONLY       = 1
+	SQL_SO_KEYSET_DRIVEN      = 2
+	SQL_SO_DYNAMIC            = 4
+	SQL_SO_MIXED              = 8
+	SQL_SO_STATIC             = 16
+	SQL_BS_SELECT_EXPLICIT    = 1
+	SQL_BS_ROW_COUNT_EXPLICIT = 2
+	SQL_BS_SELECT_PROC        = 4
+	SQL_BS_ROW_COUNT_PROC     = 8
+	SQL_GD_ANY_COLUMN         = 1
+	SQL_GD_ANY_ORDER          = 2
+	SQL_GD_BLOCK              = 4
+	SQL_GD_BOUND              = 8
+	SQL_CA1_BULK_ADD          = 0x10000
+
+	SQL_API_SQLCANCEL           = 5
+	SQL_API_SQLBULKOPERATIONS   = 24
+	SQL_API_SQLCOLUMNS          = 40
+	SQL_API_SQLGETDATA          = 43
+	SQL_API_SQLPARAMDATA        = 48
+	SQL_API_SQLPUTDATA          = 49
+	SQL_API_SQLSPECIALCOLUMNS   = 52
+	SQL_API_SQLSTATISTICS       = 53
+	SQL_API_SQLTABLES           = 54
+	SQL_API_SQLDESCRIBEPARAM    = 58
+	SQL_API_SQLFOREIGNKEYS      = 60
+	SQL_API_SQLMORERESULTS      = 61
+	SQL_API_SQLNUMPARAMS        = 63
+	SQL_API_SQLPRIMARYKEYS      = 65
+	SQL_API_SQLPROCEDURECOLUMNS = 66
+	SQL_API_SQLPROCEDURES       = 67
+	SQL_API_SQLSETPOS           = 68
+	SQL_API_SQLFETCHSCROLL      = 1021
 
 	SQL_ADD         = 4
 	SQL_ROW_ADDED   = 4
 	SQL_FETCH_FIRST = 2
+	SQL_FETCH_LAST  = 3
+
+	SQL_FETCH_FIRST_USER   = 31
+	SQL_FETCH_FIRST_SYSTEM = 32
+
+	SQL_BIND_BY_COLUMN      = uintptr(0)
+	SQL_FETCH_NEXT          = 1
+	SQL_CURSOR_FORWARD_ONLY = uintptr(0)
+
+	SQL_ATTR_PARAM_BIND_TYPE      = 18
+	SQL_PARAM_BIND_BY_COLUMN      = uintptr(0)
+	SQL_ATTR_PARAMSET_SIZE        = 22
+	SQL_ATTR_PARAM_STATUS_PTR     = 20
+	SQL_ATTR_PARAMS_PROCESSED_PTR = 21
+
+	SQL_PARAM_SUCCESS           = 0
+	SQL_PARAM_SUCCESS_WITH_INFO = 6
+	SQL_PARAM_ERROR             = 5
+	SQL_PARAM_UNUSED            = 7
+	SQL_PARAM_DIAG_UNAVAILABLE  = 1
+
+	SQL_ATTR_QUERY_TIMEOUT = 0
+	SQL_ATTR_MAX_ROWS      = 1
+	SQL_ATTR_NOSCAN        = 2
+	SQL_NOSCAN_OFF         = uintptr(0)
+	SQL_NOSCAN_ON          = uintptr(1)
+	SQL_CURSOR_STATIC      = uintptr(3)
+	SQL_CONCUR_READ_ONLY   = uintptr(1)
+	SQL_CONCUR_VALUES      = uintptr(4)
+	SQL_NONSCROLLABLE      = uintptr(0)
+
+	SQL_FETCH_PRIOR             = 4
+	SQL_FETCH_ABSOLUTE          = 5
+	SQL_FETCH_RELATIVE          = 6
+	SQL_FETCH_BOOKMARK          = 8
+	SQL_ATTR_FETCH_BOOKMARK_PTR = 16
+	SQL_UB_OFF                  = uintptr(0)
+
+	SQL_DIAG_CURSOR_ROW_COUNT = -1249
+	SQL_DIAG_DYNAMIC_FUNCTION = 7
+	SQL_DIAG_ROW_NUMBER       = -1248
+	SQL_DIAG_COLUMN_NUMBER    = -1247
+	SQL_DIAG_SERVER_NAME      = 11
+	SQL_DIAG_CONNECTION_NAME  = 10
+	SQL_DIAG_CLASS_ORIGIN     = 8
+	SQL_DIAG_SUBCLASS_ORIGIN  = 9
 )
 
 type (
@@ -79,6 +79,11 @@ func SQLFreeHandle(handleType SQLSMALLINT, handle SQLHANDLE) (ret SQLRETURN) {
 	return SQLRETURN(r)
 }
 
+func SQLFreeStmt(statementHandle SQLHSTMT, option SQLUSMALLINT) (ret SQLRETURN) {
+	r := C.SQLFreeStmt(C.SQLHSTMT(statementHandle), C.SQLUSMALLINT(option))
+	return SQLRETURN(r)
+}
+
 func SQLGetData(statementHandle SQLHSTMT, colOrParamNum SQLUSMALLINT, targetType SQLSMALLINT, targetValuePtr SQLPOINTER, bufferLength SQLLEN, vallen *SQLLEN) (ret SQLRETURN) {
 	r := C.SQLGetData(C.SQLHSTMT(statementHandle), C.SQLUSMALLINT(colOrParamNum), C.SQLSMALLINT(targetType), C.SQLPOINTER(targetValuePtr), C.SQLLEN(bufferLength), (*C.SQLLEN)(vallen))
 	return SQLRETURN(r)
@@ -89,6 +94,11 @@ func SQLGetDiagRec(handleType SQLSMALLINT, handle SQLHANDLE, recNumber SQLSMALLI
 	return SQLRETURN(r)
 }
 
+func SQLGetDiagField(handleType SQLSMALLINT, handle SQLHANDLE, recNumber SQLSMALLINT, diagIdentifier SQLSMALLINT, diagInfoPtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLGetDiagFieldW(C.SQLSMALLINT(handleType), C.SQLHANDLE(handle), C.SQLSMALLINT(recNumber), C.SQLSMALLINT(diagIdentifier), C.SQLPOINTER(diagInfoPtr), C.SQLSMALLINT(bufferLength), (*C.SQLSMALLINT)(stringLengthPtr))
+	return SQLRETURN(r)
+}
+
 func SQLNumParams(statementHandle SQLHSTMT, parameterCountPtr *SQLSMALLINT) (ret SQLRETURN) {
 	r := C.SQLNumParams(C.SQLHSTMT(statementHandle), (*C.SQLSMALLINT)(parameterCountPtr))
 	return SQLRETURN(r)
@@ -114,6 +124,11 @@ func SQLSetEnvAttr(environmentHandle SQLHENV, attribute SQLINTEGER, valuePtr SQL
 	return SQLRETURN(r)
 }
 
+func SQLGetConnectAttr(connectionHandle SQLHDBC, attribute SQLINTEGER, valuePtr SQLPOINTER, bufferLength SQLINTEGER, stringLengthPtr *SQLINTEGER) (ret SQLRETURN) {
+	r := C.SQLGetConnectAttrW(C.SQLHDBC(connectionHandle), C.SQLINTEGER(attribute), C.SQLPOINTER(valuePtr), C.SQLINTEGER(bufferLength), (*C.SQLINTEGER)(stringLengthPtr))
+	return SQLRETURN(r)
+}
+
 func SQLSetConnectAttr(connectionHandle SQLHDBC, attribute SQLINTEGER, valuePtr SQLPOINTER, stringLength SQLINTEGER) (ret SQLRETURN) {
 	r := C.SQLSetConnectAttrW(C.SQLHDBC(connectionHandle), C.SQLINTEGER(attribute), C.SQLPOINTER(valuePtr), C.SQLINTEGER(stringLength))
 	return SQLRETURN(r)
@@ -124,6 +139,11 @@ func SQLSetStmtAttr(statementHandle SQLHSTMT, attribute SQLINTEGER, valuePtr SQL
 	return SQLRETURN(r)
 }
 
+func SQLGetStmtAttr(statementHandle SQLHSTMT, attribute SQLINTEGER, valuePtr SQLPOINTER, bufferLength SQLINTEGER, stringLengthPtr *SQLINTEGER) (ret SQLRETURN) {
+	r := C.SQLGetStmtAttrW(C.SQLHSTMT(statementHandle), C.SQLINTEGER(attribute), C.SQLPOINTER(valuePtr), C.SQLINTEGER(bufferLength), (*C.SQLINTEGER)(stringLengthPtr))
+	return SQLRETURN(r)
+}
+
 func SQLExecDirect(statementHandle SQLHSTMT, statementText *SQLWCHAR, textLength SQLINTEGER) (ret SQLRETURN) {
 	r := C.SQLExecDirectW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(statementText)), C.SQLINTEGER(textLength))
 	return SQLRETURN(r)
@@ -135,7 +155,7 @@ func SQLColAttribute(statementHandle SQLHSTMT, columnNumber SQLUSMALLINT, fieldI
 }
 
 func SQLGetInfo(connectionHandle SQLHDBC, infoType SQLUSMALLINT, infoValuePtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) {
-	r := C.SQLGetInfo(C.SQLHDBC(connectionHandle), C.SQLUSMALLINT(infoType), C.SQLPOINTER(infoValuePtr), C.SQLSMALLINT(bufferLength), (*C.SQLSMALLINT)(stringLengthPtr))
+	r := C.SQLGetInfoW(C.SQLHDBC(connectionHandle), C.SQLUSMALLINT(infoType), C.SQLPOINTER(infoValuePtr), C.SQLSMALLINT(bufferLength), (*C.SQLSMALLINT)(stringLengthPtr))
 	return SQLRETURN(r)
 }
 
@@ -158,3 +178,68 @@ func SQLFetchScroll(statementHandle SQLHSTMT, fetchOrientation SQLSMALLINT, fetc
 	r := C.SQLFetchScroll(C.SQLHSTMT(statementHandle), C.SQLSMALLINT(fetchOrientation), C.SQLLEN(fetchOffset))
 	return SQLRETURN(r)
 }
+
+func SQLParamData(statementHandle SQLHSTMT, valuePtrPtr *SQLPOINTER) (ret SQLRETURN) {
+	r := C.SQLParamData(C.SQLHSTMT(statementHandle), (*C.SQLPOINTER)(valuePtrPtr))
+	return SQLRETURN(r)
+}
+
+func SQLPutData(statementHandle SQLHSTMT, dataPtr SQLPOINTER, strLen_or_Ind SQLLEN) (ret SQLRETURN) {
+	r := C.SQLPutData(C.SQLHSTMT(statementHandle), C.SQLPOINTER(dataPtr), C.SQLLEN(strLen_or_Ind))
+	return SQLRETURN(r)
+}
+
+func SQLTables(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, tableType *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLTablesW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(tableName)), C.SQLSMALLINT(nameLength3), (*C.SQLWCHAR)(unsafe.Pointer(tableType)), C.SQLSMALLINT(nameLength4))
+	return SQLRETURN(r)
+}
+
+func SQLColumns(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, columnName *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLColumnsW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(tableName)), C.SQLSMALLINT(nameLength3), (*C.SQLWCHAR)(unsafe.Pointer(columnName)), C.SQLSMALLINT(nameLength4))
+	return SQLRETURN(r)
+}
+
+func SQLPrimaryKeys(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLPrimaryKeysW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(tableName)), C.SQLSMALLINT(nameLength3))
+	return SQLRETURN(r)
+}
+
+func SQLForeignKeys(statementHandle SQLHSTMT, pkCatalogName *SQLWCHAR, nameLength1 SQLSMALLINT, pkSchemaName *SQLWCHAR, nameLength2 SQLSMALLINT, pkTableName *SQLWCHAR, nameLength3 SQLSMALLINT, fkCatalogName *SQLWCHAR, nameLength4 SQLSMALLINT, fkSchemaName *SQLWCHAR, nameLength5 SQLSMALLINT, fkTableName *SQLWCHAR, nameLength6 SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLForeignKeysW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(pkCatalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(pkSchemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(pkTableName)), C.SQLSMALLINT(nameLength3), (*C.SQLWCHAR)(unsafe.Pointer(fkCatalogName)), C.SQLSMALLINT(nameLength4), (*C.SQLWCHAR)(unsafe.Pointer(fkSchemaName)), C.SQLSMALLINT(nameLength5), (*C.SQLWCHAR)(unsafe.Pointer(fkTableName)), C.SQLSMALLINT(nameLength6))
+	return SQLRETURN(r)
+}
+
+func SQLStatistics(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, unique SQLUSMALLINT, reserved SQLUSMALLINT) (ret SQLRETURN) {
+	r := C.SQLStatisticsW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(tableName)), C.SQLSMALLINT(nameLength3), C.SQLUSMALLINT(unique), C.SQLUSMALLINT(reserved))
+	return SQLRETURN(r)
+}
+
+func SQLSpecialColumns(statementHandle SQLHSTMT, identifierType SQLUSMALLINT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, scope SQLUSMALLINT, nullable SQLUSMALLINT) (ret SQLRETURN) {
+	r := C.SQLSpecialColumnsW(C.SQLHSTMT(statementHandle), C.SQLUSMALLINT(identifierType), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(tableName)), C.SQLSMALLINT(nameLength3), C.SQLUSMALLINT(scope), C.SQLUSMALLINT(nullable))
+	return SQLRETURN(r)
+}
+
+func SQLProcedures(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, procName *SQLWCHAR, nameLength3 SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLProceduresW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(procName)), C.SQLSMALLINT(nameLength3))
+	return SQLRETURN(r)
+}
+
+func SQLProcedureColumns(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, procName *SQLWCHAR, nameLength3 SQLSMALLINT, columnName *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLProcedureColumnsW(C.SQLHSTMT(statementHandle), (*C.SQLWCHAR)(unsafe.Pointer(catalogName)), C.SQLSMALLINT(nameLength1), (*C.SQLWCHAR)(unsafe.Pointer(schemaName)), C.SQLSMALLINT(nameLength2), (*C.SQLWCHAR)(unsafe.Pointer(procName)), C.SQLSMALLINT(nameLength3), (*C.SQLWCHAR)(unsafe.Pointer(columnName)), C.SQLSMALLINT(nameLength4))
+	return SQLRETURN(r)
+}
+
+func SQLGetFunctions(connectionHandle SQLHDBC, functionId SQLUSMALLINT, supportedPtr *SQLUSMALLINT) (ret SQLRETURN) {
+	r := C.SQLGetFunctions(C.SQLHDBC(connectionHandle), C.SQLUSMALLINT(functionId), (*C.SQLUSMALLINT)(supportedPtr))
+	return SQLRETURN(r)
+}
+
+func SQLDrivers(environmentHandle SQLHENV, direction SQLUSMALLINT, driverDescription *SQLWCHAR, bufferLength1 SQLSMALLINT, descriptionLengthPtr *SQLSMALLINT, driverAttributes *SQLWCHAR, bufferLength2 SQLSMALLINT, attributesLengthPtr *SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLDriversW(C.SQLHENV(environmentHandle), C.SQLUSMALLINT(direction), (*C.SQLWCHAR)(unsafe.Pointer(driverDescription)), C.SQLSMALLINT(bufferLength1), (*C.SQLSMALLINT)(descriptionLengthPtr), (*C.SQLWCHAR)(unsafe.Pointer(driverAttributes)), C.SQLSMALLINT(bufferLength2), (*C.SQLSMALLINT)(attributesLengthPtr))
+	return SQLRETURN(r)
+}
+
+func SQLDataSources(environmentHandle SQLHENV, direction SQLUSMALLINT, serverName *SQLWCHAR, bufferLength1 SQLSMALLINT, nameLength1Ptr *SQLSMALLINT, description *SQLWCHAR, bufferLength2 SQLSMALLINT, nameLength2Ptr *SQLSMALLINT) (ret SQLRETURN) {
+	r := C.SQLDataSourcesW(C.SQLHENV(environmentHandle), C.SQLUSMALLINT(direction), (*C.SQLWCHAR)(unsafe.Pointer(serverName)), C.SQLSMALLINT(bufferLength1), (*C.SQLSMALLINT)(nameLength1Ptr), (*C.SQLWCHAR)(unsafe.Pointer(description)), C.SQLSMALLINT(bufferLength2), (*C.SQLSMALLINT)(nameLength2Ptr))
+	return SQLRETURN(r)
+}
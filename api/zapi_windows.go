@@ -39,34 +39,51 @@ func errnoErr(e syscall.Errno) error {
 var (
 	mododbc32 = windows.NewLazySystemDLL("odbc32.dll")
 
-	procSQLAllocHandle     = mododbc32.NewProc("SQLAllocHandle")
-	procSQLBindCol         = mododbc32.NewProc("SQLBindCol")
-	procSQLBindParameter   = mododbc32.NewProc("SQLBindParameter")
-	procSQLCloseCursor     = mododbc32.NewProc("SQLCloseCursor")
-	procSQLDescribeColW    = mododbc32.NewProc("SQLDescribeColW")
-	procSQLDescribeParam   = mododbc32.NewProc("SQLDescribeParam")
-	procSQLDisconnect      = mododbc32.NewProc("SQLDisconnect")
-	procSQLDriverConnectW  = mododbc32.NewProc("SQLDriverConnectW")
-	procSQLEndTran         = mododbc32.NewProc("SQLEndTran")
-	procSQLExecute         = mododbc32.NewProc("SQLExecute")
-	procSQLFetch           = mododbc32.NewProc("SQLFetch")
-	procSQLFreeHandle      = mododbc32.NewProc("SQLFreeHandle")
-	procSQLGetData         = mododbc32.NewProc("SQLGetData")
-	procSQLGetDiagRecW     = mododbc32.NewProc("SQLGetDiagRecW")
-	procSQLNumParams       = mododbc32.NewProc("SQLNumParams")
-	procSQLNumResultCols   = mododbc32.NewProc("SQLNumResultCols")
-	procSQLPrepareW        = mododbc32.NewProc("SQLPrepareW")
-	procSQLRowCount        = mododbc32.NewProc("SQLRowCount")
-	procSQLSetEnvAttr      = mododbc32.NewProc("SQLSetEnvAttr")
-	procSQLSetConnectAttrW = mododbc32.NewProc("SQLSetConnectAttrW")
-	procSQLSetStmtAttrW    = mododbc32.NewProc("SQLSetStmtAttrW")
-	procSQLExecDirectW     = mododbc32.NewProc("SQLExecDirectW")
-	procSQLColAttribute    = mododbc32.NewProc("SQLColAttribute")
-	procSQLGetInfo         = mododbc32.NewProc("SQLGetInfo")
-	procSQLCancel          = mododbc32.NewProc("SQLCancel")
-	procSQLMoreResults     = mododbc32.NewProc("SQLMoreResults")
-	procSQLBulkOperations  = mododbc32.NewProc("SQLBulkOperations")
-	procSQLFetchScroll     = mododbc32.NewProc("SQLFetchScroll")
+	procSQLAllocHandle       = mododbc32.NewProc("SQLAllocHandle")
+	procSQLBindCol           = mododbc32.NewProc("SQLBindCol")
+	procSQLBindParameter     = mododbc32.NewProc("SQLBindParameter")
+	procSQLCloseCursor       = mododbc32.NewProc("SQLCloseCursor")
+	procSQLDescribeColW      = mododbc32.NewProc("SQLDescribeColW")
+	procSQLDescribeParam     = mododbc32.NewProc("SQLDescribeParam")
+	procSQLDisconnect        = mododbc32.NewProc("SQLDisconnect")
+	procSQLDriverConnectW    = mododbc32.NewProc("SQLDriverConnectW")
+	procSQLEndTran           = mododbc32.NewProc("SQLEndTran")
+	procSQLExecute           = mododbc32.NewProc("SQLExecute")
+	procSQLFetch             = mododbc32.NewProc("SQLFetch")
+	procSQLFreeHandle        = mododbc32.NewProc("SQLFreeHandle")
+	procSQLFreeStmt          = mododbc32.NewProc("SQLFreeStmt")
+	procSQLGetData           = mododbc32.NewProc("SQLGetData")
+	procSQLGetDiagRecW       = mododbc32.NewProc("SQLGetDiagRecW")
+	procSQLGetDiagFieldW     = mododbc32.NewProc("SQLGetDiagFieldW")
+	procSQLNumParams         = mododbc32.NewProc("SQLNumParams")
+	procSQLNumResultCols     = mododbc32.NewProc("SQLNumResultCols")
+	procSQLPrepareW          = mododbc32.NewProc("SQLPrepareW")
+	procSQLRowCount          = mododbc32.NewProc("SQLRowCount")
+	procSQLSetEnvAttr        = mododbc32.NewProc("SQLSetEnvAttr")
+	procSQLGetConnectAttrW   = mododbc32.NewProc("SQLGetConnectAttrW")
+	procSQLSetConnectAttrW   = mododbc32.NewProc("SQLSetConnectAttrW")
+	procSQLSetStmtAttrW      = mododbc32.NewProc("SQLSetStmtAttrW")
+	procSQLGetStmtAttrW      = mododbc32.NewProc("SQLGetStmtAttrW")
+	procSQLExecDirectW       = mododbc32.NewProc("SQLExecDirectW")
+	procSQLColAttribute      = mododbc32.NewProc("SQLColAttribute")
+	procSQLGetInfoW          = mododbc32.NewProc("SQLGetInfoW")
+	procSQLCancel            = mododbc32.NewProc("SQLCancel")
+	procSQLMoreResults       = mododbc32.NewProc("SQLMoreResults")
+	procSQLBulkOperations    = mododbc32.NewProc("SQLBulkOperations")
+	procSQLFetchScroll       = mododbc32.NewProc("SQLFetchScroll")
+	procSQLParamData         = mododbc32.NewProc("SQLParamData")
+	procSQLPutData           = mododbc32.NewProc("SQLPutData")
+	procSQLTablesW           = mododbc32.NewProc("SQLTablesW")
+	procSQLColumnsW          = mododbc32.NewProc("SQLColumnsW")
+	procSQLPrimaryKeysW      = mododbc32.NewProc("SQLPrimaryKeysW")
+	procSQLForeignKeysW      = mododbc32.NewProc("SQLForeignKeysW")
+	procSQLStatisticsW       = mododbc32.NewProc("SQLStatisticsW")
+	procSQLSpecialColumnsW   = mododbc32.NewProc("SQLSpecialColumnsW")
+	procSQLProceduresW       = mododbc32.NewProc("SQLProceduresW")
+	procSQLProcedureColumnsW = mododbc32.NewProc("SQLProcedureColumnsW")
+	procSQLGetFunctions      = mododbc32.NewProc("SQLGetFunctions")
+	procSQLDriversW          = mododbc32.NewProc("SQLDriversW")
+	procSQLDataSourcesW      = mododbc32.NewProc("SQLDataSourcesW")
 )
 
 func SQLAllocHandle(handleType SQLSMALLINT, inputHandle SQLHANDLE, outputHandle *SQLHANDLE) (ret SQLRETURN) {
@@ -141,6 +158,12 @@ func SQLFreeHandle(handleType SQLSMALLINT, handle SQLHANDLE) (ret SQLRETURN) {
 	return
 }
 
+func SQLFreeStmt(statementHandle SQLHSTMT, option SQLUSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall(procSQLFreeStmt.Addr(), 2, uintptr(statementHandle), uintptr(option), 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
 func SQLGetData(statementHandle SQLHSTMT, colOrParamNum SQLUSMALLINT, targetType SQLSMALLINT, targetValuePtr SQLPOINTER, bufferLength SQLLEN, vallen *SQLLEN) (ret SQLRETURN) {
 	r0, _, _ := syscall.Syscall6(procSQLGetData.Addr(), 6, uintptr(statementHandle), uintptr(colOrParamNum), uintptr(targetType), uintptr(targetValuePtr), uintptr(bufferLength), uintptr(unsafe.Pointer(vallen)))
 	ret = SQLRETURN(r0)
@@ -153,6 +176,12 @@ func SQLGetDiagRec(handleType SQLSMALLINT, handle SQLHANDLE, recNumber SQLSMALLI
 	return
 }
 
+func SQLGetDiagField(handleType SQLSMALLINT, handle SQLHANDLE, recNumber SQLSMALLINT, diagIdentifier SQLSMALLINT, diagInfoPtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLGetDiagFieldW.Addr(), 7, uintptr(handleType), uintptr(handle), uintptr(recNumber), uintptr(diagIdentifier), uintptr(diagInfoPtr), uintptr(bufferLength), uintptr(unsafe.Pointer(stringLengthPtr)), 0, 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
 func SQLNumParams(statementHandle SQLHSTMT, parameterCountPtr *SQLSMALLINT) (ret SQLRETURN) {
 	r0, _, _ := syscall.Syscall(procSQLNumParams.Addr(), 2, uintptr(statementHandle), uintptr(unsafe.Pointer(parameterCountPtr)), 0)
 	ret = SQLRETURN(r0)
@@ -183,6 +212,12 @@ func SQLSetEnvAttr(environmentHandle SQLHENV, attribute SQLINTEGER, valuePtr SQL
 	return
 }
 
+func SQLGetConnectAttr(connectionHandle SQLHDBC, attribute SQLINTEGER, valuePtr SQLPOINTER, bufferLength SQLINTEGER, stringLengthPtr *SQLINTEGER) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall6(procSQLGetConnectAttrW.Addr(), 5, uintptr(connectionHandle), uintptr(attribute), uintptr(valuePtr), uintptr(bufferLength), uintptr(unsafe.Pointer(stringLengthPtr)), 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
 func SQLSetConnectAttr(connectionHandle SQLHDBC, attribute SQLINTEGER, valuePtr SQLPOINTER, stringLength SQLINTEGER) (ret SQLRETURN) {
 	r0, _, _ := syscall.Syscall6(procSQLSetConnectAttrW.Addr(), 4, uintptr(connectionHandle), uintptr(attribute), uintptr(valuePtr), uintptr(stringLength), 0, 0)
 	ret = SQLRETURN(r0)
@@ -195,6 +230,12 @@ func SQLSetStmtAttr(statementHandle SQLHSTMT, attribute SQLINTEGER, valuePtr SQL
 	return
 }
 
+func SQLGetStmtAttr(statementHandle SQLHSTMT, attribute SQLINTEGER, valuePtr SQLPOINTER, bufferLength SQLINTEGER, stringLengthPtr *SQLINTEGER) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall6(procSQLGetStmtAttrW.Addr(), 5, uintptr(statementHandle), uintptr(attribute), uintptr(valuePtr), uintptr(bufferLength), uintptr(unsafe.Pointer(stringLengthPtr)), 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
 func SQLExecDirect(statementHandle SQLHSTMT, statementText *SQLWCHAR, textLength SQLINTEGER) (ret SQLRETURN) {
 	r0, _, _ := syscall.Syscall(procSQLExecDirectW.Addr(), 3, uintptr(statementHandle), uintptr(unsafe.Pointer(statementText)), uintptr(textLength))
 	ret = SQLRETURN(r0)
@@ -208,7 +249,7 @@ func SQLColAttribute(statementHandle SQLHSTMT, columnNumber SQLUSMALLINT, fieldI
 }
 
 func SQLGetInfo(connectionHandle SQLHDBC, infoType SQLUSMALLINT, infoValuePtr SQLPOINTER, bufferLength SQLSMALLINT, stringLengthPtr *SQLSMALLINT) (ret SQLRETURN) {
-	r0, _, _ := syscall.Syscall6(procSQLGetInfo.Addr(), 5, uintptr(connectionHandle), uintptr(infoType), uintptr(infoValuePtr), uintptr(bufferLength), uintptr(unsafe.Pointer(stringLengthPtr)), 0)
+	r0, _, _ := syscall.Syscall6(procSQLGetInfoW.Addr(), 5, uintptr(connectionHandle), uintptr(infoType), uintptr(infoValuePtr), uintptr(bufferLength), uintptr(unsafe.Pointer(stringLengthPtr)), 0)
 	ret = SQLRETURN(r0)
 	return
 }
@@ -236,3 +277,81 @@ func SQLFetchScroll(statementHandle SQLHSTMT, fetchOrientation SQLSMALLINT, fetc
 	ret = SQLRETURN(r0)
 	return
 }
+
+func SQLParamData(statementHandle SQLHSTMT, valuePtrPtr *SQLPOINTER) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall(procSQLParamData.Addr(), 2, uintptr(statementHandle), uintptr(unsafe.Pointer(valuePtrPtr)), 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLPutData(statementHandle SQLHSTMT, dataPtr SQLPOINTER, strLen_or_Ind SQLLEN) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall(procSQLPutData.Addr(), 3, uintptr(statementHandle), uintptr(dataPtr), uintptr(strLen_or_Ind))
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLTables(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, tableType *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLTablesW.Addr(), 9, uintptr(statementHandle), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(tableName)), uintptr(nameLength3), uintptr(unsafe.Pointer(tableType)), uintptr(nameLength4))
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLColumns(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, columnName *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLColumnsW.Addr(), 9, uintptr(statementHandle), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(tableName)), uintptr(nameLength3), uintptr(unsafe.Pointer(columnName)), uintptr(nameLength4))
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLPrimaryKeys(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLPrimaryKeysW.Addr(), 7, uintptr(statementHandle), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(tableName)), uintptr(nameLength3), 0, 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLForeignKeys(statementHandle SQLHSTMT, pkCatalogName *SQLWCHAR, nameLength1 SQLSMALLINT, pkSchemaName *SQLWCHAR, nameLength2 SQLSMALLINT, pkTableName *SQLWCHAR, nameLength3 SQLSMALLINT, fkCatalogName *SQLWCHAR, nameLength4 SQLSMALLINT, fkSchemaName *SQLWCHAR, nameLength5 SQLSMALLINT, fkTableName *SQLWCHAR, nameLength6 SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall15(procSQLForeignKeysW.Addr(), 13, uintptr(statementHandle), uintptr(unsafe.Pointer(pkCatalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(pkSchemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(pkTableName)), uintptr(nameLength3), uintptr(unsafe.Pointer(fkCatalogName)), uintptr(nameLength4), uintptr(unsafe.Pointer(fkSchemaName)), uintptr(nameLength5), uintptr(unsafe.Pointer(fkTableName)), uintptr(nameLength6), 0, 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLStatistics(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, unique SQLUSMALLINT, reserved SQLUSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLStatisticsW.Addr(), 9, uintptr(statementHandle), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(tableName)), uintptr(nameLength3), uintptr(unique), uintptr(reserved))
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLSpecialColumns(statementHandle SQLHSTMT, identifierType SQLUSMALLINT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, tableName *SQLWCHAR, nameLength3 SQLSMALLINT, scope SQLUSMALLINT, nullable SQLUSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall12(procSQLSpecialColumnsW.Addr(), 10, uintptr(statementHandle), uintptr(identifierType), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(tableName)), uintptr(nameLength3), uintptr(scope), uintptr(nullable), 0, 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLProcedures(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, procName *SQLWCHAR, nameLength3 SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLProceduresW.Addr(), 7, uintptr(statementHandle), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(procName)), uintptr(nameLength3), 0, 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLProcedureColumns(statementHandle SQLHSTMT, catalogName *SQLWCHAR, nameLength1 SQLSMALLINT, schemaName *SQLWCHAR, nameLength2 SQLSMALLINT, procName *SQLWCHAR, nameLength3 SQLSMALLINT, columnName *SQLWCHAR, nameLength4 SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLProcedureColumnsW.Addr(), 9, uintptr(statementHandle), uintptr(unsafe.Pointer(catalogName)), uintptr(nameLength1), uintptr(unsafe.Pointer(schemaName)), uintptr(nameLength2), uintptr(unsafe.Pointer(procName)), uintptr(nameLength3), uintptr(unsafe.Pointer(columnName)), uintptr(nameLength4))
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLGetFunctions(connectionHandle SQLHDBC, functionId SQLUSMALLINT, supportedPtr *SQLUSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall(procSQLGetFunctions.Addr(), 3, uintptr(connectionHandle), uintptr(functionId), uintptr(unsafe.Pointer(supportedPtr)))
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLDrivers(environmentHandle SQLHENV, direction SQLUSMALLINT, driverDescription *SQLWCHAR, bufferLength1 SQLSMALLINT, descriptionLengthPtr *SQLSMALLINT, driverAttributes *SQLWCHAR, bufferLength2 SQLSMALLINT, attributesLengthPtr *SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLDriversW.Addr(), 8, uintptr(environmentHandle), uintptr(direction), uintptr(unsafe.Pointer(driverDescription)), uintptr(bufferLength1), uintptr(unsafe.Pointer(descriptionLengthPtr)), uintptr(unsafe.Pointer(driverAttributes)), uintptr(bufferLength2), uintptr(unsafe.Pointer(attributesLengthPtr)), 0)
+	ret = SQLRETURN(r0)
+	return
+}
+
+func SQLDataSources(environmentHandle SQLHENV, direction SQLUSMALLINT, serverName *SQLWCHAR, bufferLength1 SQLSMALLINT, nameLength1Ptr *SQLSMALLINT, description *SQLWCHAR, bufferLength2 SQLSMALLINT, nameLength2Ptr *SQLSMALLINT) (ret SQLRETURN) {
+	r0, _, _ := syscall.Syscall9(procSQLDataSourcesW.Addr(), 8, uintptr(environmentHandle), uintptr(direction), uintptr(unsafe.Pointer(serverName)), uintptr(bufferLength1), uintptr(unsafe.Pointer(nameLength1Ptr)), uintptr(unsafe.Pointer(description)), uintptr(bufferLength2), uintptr(unsafe.Pointer(nameLength2Ptr)), 0)
+	ret = SQLRETURN(r0)
+	return
+}
@@ -12,6 +12,7 @@ type DataFrame struct {
 	ncols      int
 	nrows      int
 	tableName  string
+	fetchSize  int
 }
 
 func NewDataframe(columninfos ...ColumnInfo) *DataFrame {
@@ -69,5 +70,11 @@ func (df *DataFrame) SetTableName(name string) {
 	df.tableName = name
 }
 
+// SetFetchSize sets the number of rows ReadODBC fetches at once.
+// Zero uses odbc.FETCH_SIZE.
+func (df *DataFrame) SetFetchSize(size int) {
+	df.fetchSize = size
+}
+
 func (df *DataFrame) Close() {
 }
@@ -22,6 +22,7 @@ func (df *DataFrame) getColumns(conn *odbc.Connection, table string) error {
 	if err != nil {
 		return err
 	}
+	stmt.SetFetchSize(df.fetchSize)
 	err = stmt.Execute()
 	if err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jooita/sql/api"
 	"github.com/jooita/sql/odbc"
 )
 
@@ -25,8 +26,15 @@ func (df *DataFrame) WriteODBC(dsn, table string, savemode mode) error {
 	if err != nil {
 		return err
 	}
+	// rows are inserted with SQLBulkOperations(SQL_ADD). If the driver
+	// cannot tell, the insert is tried anyway.
+	if bulk, err := conn.SupportsFunction(api.SQL_API_SQLBULKOPERATIONS); err == nil && !bulk {
+		conn.Close()
+		return errors.New("dataframe: the driver does not support SQLBulkOperations")
+	}
 	_, err = df.getColumnInfo(conn, table)
 	if err != nil {
+		conn.Close()
 		return err
 	}
 
@@ -0,0 +1,47 @@
+package driver
+
+import "github.com/jooita/sql/odbc"
+
+// stmtCache keeps the idle prepared statements of a connection for
+// reuse. A statement is taken out while in use and put back when it
+// is closed; the least recently used one is closed when the cache is
+// full.
+type stmtCache struct {
+	size int
+	// idle is ordered from the least to the most recently used.
+	idle []cachedStmt
+}
+
+type cachedStmt struct {
+	query string
+	st    *odbc.Statement
+}
+
+// get takes an idle statement prepared with query out of the cache.
+func (c *stmtCache) get(query string) *odbc.Statement {
+	for i := len(c.idle) - 1; i >= 0; i-- {
+		if c.idle[i].query == query {
+			st := c.idle[i].st
+			c.idle = append(c.idle[:i], c.idle[i+1:]...)
+			return st
+		}
+	}
+	return nil
+}
+
+// put returns st to the cache.
+func (c *stmtCache) put(query string, st *odbc.Statement) {
+	if len(c.idle) >= c.size {
+		c.idle[0].st.Close()
+		c.idle = append(c.idle[:0], c.idle[1:]...)
+	}
+	c.idle = append(c.idle, cachedStmt{query: query, st: st})
+}
+
+// clear closes all idle statements.
+func (c *stmtCache) clear() {
+	for _, s := range c.idle {
+		s.st.Close()
+	}
+	c.idle = nil
+}
@@ -0,0 +1,108 @@
+package driver
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
+)
+
+var (
+	scanTypeByte    = reflect.TypeOf(byte(0))
+	scanTypeInt     = reflect.TypeOf(int(0))
+	scanTypeInt64   = reflect.TypeOf(int64(0))
+	scanTypeFloat32 = reflect.TypeOf(float32(0))
+	scanTypeFloat64 = reflect.TypeOf(float64(0))
+	scanTypeString  = reflect.TypeOf("")
+	scanTypeTime    = reflect.TypeOf(time.Time{})
+	scanTypeBytes   = reflect.TypeOf([]byte(nil))
+	scanTypeUnknown = reflect.TypeOf(new(interface{})).Elem()
+)
+
+func (r *rows) field(index int) *odbc.Field {
+	fields, err := r.describe()
+	if err != nil || index < 0 || index >= len(fields) {
+		return nil
+	}
+	return fields[index]
+}
+
+// ColumnTypeScanType returns the Go type Next stores for the column,
+// following the conversions done by odbc.Statement.GetField.
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	f := r.field(index)
+	if f == nil {
+		return scanTypeUnknown
+	}
+	switch f.Type {
+	case api.SQL_BIT:
+		return scanTypeByte
+	case api.SQL_INTEGER, api.SQL_SMALLINT, api.SQL_TINYINT:
+		return scanTypeInt
+	case api.SQL_BIGINT:
+		return scanTypeInt64
+	case api.SQL_REAL:
+		return scanTypeFloat32
+	case api.SQL_FLOAT, api.SQL_DOUBLE, api.SQL_NUMERIC, api.SQL_DECIMAL:
+		return scanTypeFloat64
+	case api.SQL_CHAR, api.SQL_VARCHAR, api.SQL_LONGVARCHAR,
+		api.SQL_WCHAR, api.SQL_WVARCHAR, api.SQL_WLONGVARCHAR:
+		return scanTypeString
+	case api.SQL_TYPE_TIMESTAMP, api.SQL_TYPE_DATE, api.SQL_TYPE_TIME, api.SQL_DATETIME:
+		return scanTypeTime
+	}
+	return scanTypeBytes
+}
+
+// ColumnTypeDatabaseTypeName returns the data source dependent type name
+// (SQL_DESC_TYPE_NAME), e.g. "VARCHAR" or "INTEGER".
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	name, err := r.s.st.ColAttributeString(index+1, api.SQL_DESC_TYPE_NAME)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// ColumnTypeLength returns the column size of character and binary columns.
+func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	f := r.field(index)
+	if f == nil {
+		return 0, false
+	}
+	switch f.Type {
+	case api.SQL_CHAR, api.SQL_VARCHAR, api.SQL_LONGVARCHAR,
+		api.SQL_WCHAR, api.SQL_WVARCHAR, api.SQL_WLONGVARCHAR,
+		api.SQL_BINARY, api.SQL_VARBINARY, api.SQL_LONGVARBINARY:
+		return int64(f.Size), true
+	}
+	return 0, false
+}
+
+// ColumnTypePrecisionScale returns the precision and scale of decimal columns.
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	f := r.field(index)
+	if f == nil {
+		return 0, 0, false
+	}
+	switch f.Type {
+	case api.SQL_NUMERIC, api.SQL_DECIMAL:
+		return int64(f.Size), int64(f.DecimalDigits), true
+	}
+	return 0, 0, false
+}
+
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	f := r.field(index)
+	if f == nil {
+		return false, false
+	}
+	switch f.Nullable {
+	case api.SQL_NULLABLE:
+		return true, true
+	case api.SQL_NO_NULLS:
+		return false, true
+	}
+	return false, false
+}
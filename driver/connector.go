@@ -0,0 +1,248 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jooita/sql/odbc"
+)
+
+// Config holds the settings of the connections opened by a Connector.
+type Config struct {
+	// DSN is the connection string passed to SQLDriverConnect.
+	DSN string
+
+	// LoginTimeout and ConnectionTimeout set SQL_ATTR_LOGIN_TIMEOUT and
+	// SQL_ATTR_CONNECTION_TIMEOUT. Zero leaves the driver default.
+	LoginTimeout      time.Duration
+	ConnectionTimeout time.Duration
+	// QueryTimeout is the default SQL_ATTR_QUERY_TIMEOUT of every
+	// statement prepared on the connections. Zero means no timeout.
+	QueryTimeout time.Duration
+	// Options are further connection attributes set by odbc.Connect.
+	Options []odbc.Option
+
+	// Isolation is the isolation level of transactions begun with
+	// sql.LevelDefault. sql.LevelDefault keeps the driver default.
+	Isolation sql.IsolationLevel
+	// StmtCacheSize is the number of idle prepared statements kept per
+	// connection for reuse by queries with the same text.
+	StmtCacheSize int
+
+	// NamedParams rewrites the :name and @name placeholders of the
+	// statements prepared with DB.Prepare to ? markers. Without it,
+	// queries are rewritten when they are executed with sql.Named
+	// arguments, which prepares them again the first time; drivers
+	// that fail to prepare the placeholders as written need it.
+	// Queries using @name for MySQL user variables or T-SQL local
+	// variables cannot be prepared with it.
+	NamedParams bool
+
+	// LastInsertId finds out the ids of inserts for sql.Result, at the
+	// cost of a query after every insert. If it is nil, the default,
+	// sql.Result.LastInsertId returns an error. DBMSLastInsertId covers
+	// MySQL, SQLite, DB2 and SQL Server; other database systems, such
+	// as PostgreSQL, Oracle and Altibase, need their own, for example
+	// SequenceCurrval.
+	LastInsertId LastInsertIdFunc
+
+	// OnConnect is called with every new connection, for example to
+	// set up the session. An error closes the connection.
+	OnConnect func(ctx context.Context, c *odbc.Connection) error
+	// OnWarning receives the warnings of the connections and of their
+	// statements, see odbc.WarningFunc.
+	OnWarning odbc.WarningFunc
+}
+
+// The connection string attributes ParseConfig takes for itself
+// instead of passing them to the ODBC driver.
+const (
+	keyQueryTimeout      = "go_query_timeout"
+	keyLoginTimeout      = "go_login_timeout"
+	keyConnectionTimeout = "go_connection_timeout"
+	keyIsolation         = "go_isolation"
+	keyStmtCacheSize     = "go_stmt_cache_size"
+	keyNamedParams       = "go_named_params"
+	keyLastInsertId      = "go_last_insert_id"
+)
+
+// ParseConfig parses a connection string, as passed to sql.Open, into a
+// Config. Besides the attributes of the ODBC driver it may contain
+//
+//	go_query_timeout       Config.QueryTimeout, as "30s" or in seconds
+//	go_login_timeout       Config.LoginTimeout
+//	go_connection_timeout  Config.ConnectionTimeout
+//	go_isolation           Config.Isolation, as "read committed" or "serializable"
+//	go_stmt_cache_size     Config.StmtCacheSize
+//	go_named_params        true sets Config.NamedParams
+//	go_last_insert_id      true sets Config.LastInsertId to DBMSLastInsertId
+//
+// which are removed from Config.DSN. The other attributes are passed
+// to the driver manager as written. A connection string that
+// odbc.ParseConnString cannot read is passed as a whole if it has none
+// of these attributes.
+func ParseConfig(dsn string) (*Config, error) {
+	cs, err := odbc.ParseConnString(dsn)
+	if err != nil {
+		if hasConfigKey(dsn) {
+			return nil, err
+		}
+		return &Config{DSN: dsn}, nil
+	}
+	cfg := &Config{}
+	for _, key := range cs.Keys() {
+		value, _ := cs.Get(key)
+		switch strings.ToLower(key) {
+		case keyQueryTimeout:
+			cfg.QueryTimeout, err = parseTimeout(key, value)
+		case keyLoginTimeout:
+			cfg.LoginTimeout, err = parseTimeout(key, value)
+		case keyConnectionTimeout:
+			cfg.ConnectionTimeout, err = parseTimeout(key, value)
+		case keyIsolation:
+			cfg.Isolation, err = parseIsolation(value)
+		case keyStmtCacheSize:
+			cfg.StmtCacheSize, err = strconv.Atoi(value)
+			if err != nil || cfg.StmtCacheSize < 0 {
+				err = fmt.Errorf("odbc: invalid %s %q", key, value)
+			}
+		case keyNamedParams:
+			cfg.NamedParams, err = parseBool(key, value)
+		case keyLastInsertId:
+			var on bool
+			if on, err = parseBool(key, value); on {
+				cfg.LastInsertId = DBMSLastInsertId
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	cfg.DSN, err = odbc.RemoveConnAttrs(dsn, isConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+var configKeys = []string{keyQueryTimeout, keyLoginTimeout, keyConnectionTimeout, keyIsolation, keyStmtCacheSize, keyNamedParams, keyLastInsertId}
+
+func isConfigKey(key string) bool {
+	for _, k := range configKeys {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasConfigKey reports whether one of the ';' separated parts of dsn
+// looks like an attribute ParseConfig takes.
+func hasConfigKey(dsn string) bool {
+	for _, part := range strings.Split(dsn, ";") {
+		if i := strings.IndexByte(part, '='); i > 0 && isConfigKey(strings.TrimSpace(part[:i])) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseBool(key, value string) (bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("odbc: invalid %s %q", key, value)
+	}
+	return b, nil
+}
+
+func parseTimeout(key, value string) (time.Duration, error) {
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("odbc: invalid %s %q", key, value)
+	}
+	return d, nil
+}
+
+func parseIsolation(value string) (sql.IsolationLevel, error) {
+	name := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(value))
+	for _, level := range []sql.IsolationLevel{
+		sql.LevelDefault,
+		sql.LevelReadUncommitted,
+		sql.LevelReadCommitted,
+		sql.LevelRepeatableRead,
+		sql.LevelSerializable,
+	} {
+		if strings.ToLower(strings.Replace(level.String(), " ", "", -1)) == name {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("odbc: invalid %s %q", keyIsolation, value)
+}
+
+type connector struct {
+	cfg Config
+	d   *Driver
+}
+
+// NewConnector returns a connector for sql.OpenDB that opens
+// connections configured by cfg.
+func NewConnector(cfg Config) driver.Connector {
+	return &connector{cfg: cfg, d: &Driver{}}
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cfg := &c.cfg
+	var params []interface{}
+	if cfg.LoginTimeout > 0 {
+		params = append(params, odbc.LoginTimeout(cfg.LoginTimeout))
+	}
+	if cfg.ConnectionTimeout > 0 {
+		params = append(params, odbc.ConnectionTimeout(cfg.ConnectionTimeout))
+	}
+	params = append(params, cfg.Options)
+	if cfg.OnWarning != nil {
+		params = append(params, cfg.OnWarning)
+	}
+	oc, err := odbc.Connect(cfg.DSN, params...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.OnConnect != nil {
+		if err := cfg.OnConnect(ctx, oc); err != nil {
+			oc.Close()
+			return nil, err
+		}
+	}
+	dc := &conn{
+		c:            oc,
+		queryTimeout: cfg.QueryTimeout,
+		isolation:    cfg.Isolation,
+		cache:        stmtCache{size: cfg.StmtCacheSize},
+		namedParams:  cfg.NamedParams,
+
+		lastInsertIdFunc: cfg.LastInsertId,
+	}
+	// OnConnect and Options may have turned autocommit off.
+	dc.autocommit = true
+	if on, err := oc.AutoCommitEnabled(); err == nil {
+		dc.autocommit = on
+	}
+	return dc, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.d
+}
@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"strings"
+
+	"github.com/jooita/sql/api"
+)
+
+// dialect holds the SQL that differs between database systems.
+type dialect struct {
+	// pingQuery is the cheapest query that needs a round trip to the server.
+	pingQuery string
+	// lastInsertId is nil if the ids of inserts cannot be found out.
+	lastInsertId LastInsertIdFunc
+}
+
+var defaultDialect = dialect{pingQuery: "select 1"}
+
+// dialects are matched against SQL_DBMS_NAME, ignoring case.
+var dialects = []struct {
+	dbms string
+	d    dialect
+}{
+	{"mysql", dialect{pingQuery: "select 1", lastInsertId: LastInsertIdQuery("select last_insert_id()")}},
+	{"mariadb", dialect{pingQuery: "select 1", lastInsertId: LastInsertIdQuery("select last_insert_id()")}},
+	// SCOPE_IDENTITY() is NULL outside the batch of the insert, which
+	// is a separate statement here. @@IDENTITY is kept by the session,
+	// but is the id generated by a trigger if the insert fired one.
+	{"sql server", dialect{pingQuery: "select 1", lastInsertId: LastInsertIdQuery("select @@identity")}},
+	// PostgreSQL has none: lastval() fails, and aborts the
+	// transaction, after an insert into a table without a sequence.
+	{"postgresql", dialect{pingQuery: "select 1"}},
+	{"sqlite", dialect{pingQuery: "select 1", lastInsertId: LastInsertIdQuery("select last_insert_rowid()")}},
+	// sequences are named freely, see SequenceCurrval.
+	{"oracle", dialect{pingQuery: "select 1 from dual"}},
+	{"altibase", dialect{pingQuery: "select 1 from dual"}},
+	{"db2", dialect{pingQuery: "values 1", lastInsertId: LastInsertIdQuery("values identity_val_local()")}},
+	{"informix", dialect{pingQuery: "select 1 from systables where tabid = 1"}},
+	{"firebird", dialect{pingQuery: "select 1 from rdb$database"}},
+}
+
+func dialectFor(dbms string) dialect {
+	dbms = strings.ToLower(dbms)
+	for _, d := range dialects {
+		if strings.Contains(dbms, d.dbms) {
+			return d.d
+		}
+	}
+	return defaultDialect
+}
+
+// getDialect returns the dialect of the connected database system,
+// looking it up on first use.
+func (c *conn) getDialect() dialect {
+	if c.dialect == nil {
+		d := defaultDialect
+		if name, err := c.c.InfoString(api.SQL_DBMS_NAME); err == nil {
+			d = dialectFor(name)
+		}
+		c.dialect = &d
+	}
+	return *c.dialect
+}
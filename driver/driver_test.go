@@ -1,12 +1,21 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"testing"
+	"time"
 	//_ "github.com/jooita/sql/driver"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
 )
 
 var (
@@ -69,3 +78,311 @@ func TestDriverOdbc(t *testing.T) {
 		t.Log(row)
 	}
 }
+
+func TestDriverQueryContextCanceled(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var dbms string
+	c, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Raw(func(dc interface{}) error {
+		dbms, err = dc.(*conn).c.InfoString(api.SQL_DBMS_NAME)
+		return err
+	})
+	c.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var query string
+	switch dbms = strings.ToLower(dbms); {
+	case strings.Contains(dbms, "mysql"), strings.Contains(dbms, "mariadb"):
+		query = "select sleep(10)"
+	case strings.Contains(dbms, "sql server"):
+		query = "waitfor delay '00:00:10'"
+	case strings.Contains(dbms, "postgresql"):
+		query = "select pg_sleep(10)"
+	default:
+		t.Skipf("no sleep statement for %s", dbms)
+	}
+
+	// the statement is already running on the server when ctx is
+	// canceled, so only SQLCancel can stop it.
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(500*time.Millisecond, cancel)
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query)
+	if err == nil {
+		rows.Close()
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("the query returned after %v", d)
+	}
+}
+
+func TestDriverBeginTxRestoresIsolation(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	c, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	isolation := func() (level int) {
+		err := c.Raw(func(dc interface{}) error {
+			var err error
+			level, err = dc.(*conn).c.IsolationLevel()
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return level
+	}
+
+	before := isolation()
+	tx, err := c.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if after := isolation(); after != before {
+		t.Fatalf("isolation level not restored: got %d, want %d", after, before)
+	}
+}
+
+func TestDriverColumnTypes(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(fmt.Sprintf("select * from %s", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != len(columns) {
+		t.Fatalf("got %d column types for %d columns", len(types), len(columns))
+	}
+	for i, ct := range types {
+		if ct.Name() != columns[i] {
+			t.Errorf("column %d: name %q != %q", i, ct.Name(), columns[i])
+		}
+		nullable, _ := ct.Nullable()
+		t.Logf("%s %s scan=%v nullable=%v", ct.Name(), ct.DatabaseTypeName(), ct.ScanType(), nullable)
+	}
+}
+
+func TestDriverExecParamTypes(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	name := *table + "_params"
+	db.Exec(fmt.Sprintf("drop table %s", name))
+	if _, err := db.Exec(fmt.Sprintf("create table %s (a timestamp, b date, c time, d decimal(20,0))", name)); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Exec(fmt.Sprintf("drop table %s", name))
+
+	// none of these are driver.Value types.
+	_, err = db.Exec(fmt.Sprintf("insert into %s values (?, ?, ?, ?)", name),
+		odbc.TimeStamp{Year: 2018, Month: 3, Day: 3, Hour: 15, Minute: 4, Second: 5},
+		odbc.Date{Year: 2018, Month: 3, Day: 3},
+		odbc.Time{Hour: 15, Minute: 4, Second: 5},
+		uint64(math.MaxUint64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d string
+	if err := db.QueryRow(fmt.Sprintf("select d from %s", name)).Scan(&d); err != nil {
+		t.Fatal(err)
+	}
+	if d != "18446744073709551615" {
+		t.Errorf("got %s, want %d", d, uint64(math.MaxUint64))
+	}
+}
+
+func TestCheckNamedValue(t *testing.T) {
+	type id uint64
+	for _, v := range []interface{}{
+		odbc.TimeStamp{}, odbc.Date{}, odbc.Time{}, uint64(math.MaxUint64), id(1), int8(1), "a", []byte("a"), odbc.Stream{},
+	} {
+		nv := &driver.NamedValue{Ordinal: 1, Value: v}
+		if err := checkNamedValue(nv); err != nil {
+			t.Errorf("%T: %v", v, err)
+		}
+	}
+	for _, v := range []interface{}{time.Time{}, nil, new(int)} {
+		if err := checkNamedValue(&driver.NamedValue{Ordinal: 1, Value: v}); err != driver.ErrSkip {
+			t.Errorf("%T: got %v, want driver.ErrSkip", v, err)
+		}
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig("DSN=test;PWD={a;b}}c};go_query_timeout=30;go_login_timeout=1m;GO_ISOLATION=Read Committed;go_stmt_cache_size=8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DSN != "DSN=test;PWD={a;b}}c};" {
+		t.Errorf("DSN: got %q", cfg.DSN)
+	}
+	if cfg.QueryTimeout != 30*time.Second || cfg.LoginTimeout != time.Minute {
+		t.Errorf("timeouts: got %v, %v", cfg.QueryTimeout, cfg.LoginTimeout)
+	}
+	if cfg.Isolation != sql.LevelReadCommitted || cfg.StmtCacheSize != 8 {
+		t.Errorf("got isolation %v, cache size %d", cfg.Isolation, cfg.StmtCacheSize)
+	}
+	if cfg.LastInsertId != nil {
+		t.Error("LastInsertId is set by default")
+	}
+	if cfg, err := ParseConfig("DSN=test;go_last_insert_id=true"); err != nil || cfg.LastInsertId == nil {
+		t.Errorf("go_last_insert_id: got %v", err)
+	}
+
+	for _, dsn := range []string{"DSN=test;PWD={abc;go_query_timeout=30", "go_query_timeout=soon", "go_isolation=snapshot"} {
+		if _, err := ParseConfig(dsn); err == nil {
+			t.Errorf("%q: expected an error", dsn)
+		}
+	}
+
+	// the driver manager reads the attributes it is given as written.
+	for dsn, want := range map[string]string{
+		"DSN=test;PWD={abc":                   "DSN=test;PWD={abc",
+		"Driver={x};Server = db ;PWD=a b":     "Driver={x};Server = db ;PWD=a b",
+		"go_isolation=serializable;DSN=test;": "DSN=test;",
+		"DSN=test;go_stmt_cache_size=4;UID=u": "DSN=test;UID=u",
+	} {
+		cfg, err := ParseConfig(dsn)
+		if err != nil {
+			t.Errorf("%q: %v", dsn, err)
+		} else if cfg.DSN != want {
+			t.Errorf("%q: got DSN %q, want %q", dsn, cfg.DSN, want)
+		}
+	}
+}
+
+func TestStmtCache(t *testing.T) {
+	c := stmtCache{size: 2}
+	a, b, d := &odbc.Statement{}, &odbc.Statement{}, &odbc.Statement{}
+	c.put("a", a)
+	c.put("b", b)
+	if st := c.get("a"); st != a {
+		t.Errorf("get a: got %p, want %p", st, a)
+	}
+	// a statement in use is not handed out twice.
+	if st := c.get("a"); st != nil {
+		t.Errorf("get a again: got %p", st)
+	}
+	c.put("a", a)
+
+	// b is now the least recently used.
+	c.put("d", d)
+	if st := c.get("b"); st != nil {
+		t.Error("b was not evicted")
+	}
+	if c.get("a") != a || c.get("d") != d {
+		t.Error("a or d was evicted")
+	}
+
+	c.put("a", a)
+	c.clear()
+	if len(c.idle) != 0 || c.get("a") != nil {
+		t.Error("clear left idle statements")
+	}
+}
+
+func TestDialectFor(t *testing.T) {
+	for dbms, want := range map[string]string{
+		"MySQL":                "select 1",
+		"Oracle":               "select 1 from dual",
+		"DB2/LINUXX8664":       "values 1",
+		"Altibase":             "select 1 from dual",
+		"Microsoft SQL Server": "select 1",
+	} {
+		if got := dialectFor(dbms).pingQuery; got != want {
+			t.Errorf("%s: got %q, want %q", dbms, got, want)
+		}
+	}
+}
+
+func TestRewriteNamed(t *testing.T) {
+	for _, tt := range []struct {
+		query, want string
+		names       []string
+	}{
+		{"select * from t where a = :a and b = @b or a = :a", "select * from t where a = ? and b = ? or a = ?", []string{"a", "b", "a"}},
+		{"select ':a', \"@b\", `:c`, 'it''s :d' from t where x = :x", "select ':a', \"@b\", `:c`, 'it''s :d' from t where x = ?", []string{"x"}},
+		{"select 1 -- :a\n, 2 /* @b */ from t where v::int = :v and @@autocommit = 1", "select 1 -- :a\n, 2 /* @b */ from t where v::int = ? and @@autocommit = 1", []string{"v"}},
+		{`select 'don\'t :stop', "\\" from t where x = :x`, `select 'don\'t :stop', "\\" from t where x = ?`, []string{"x"}},
+		{"select * from t where a = ? and b = :b", "select * from t where a = ? and b = :b", nil},
+		{"select 1", "select 1", nil},
+	} {
+		got, names := rewriteNamed(tt.query)
+		if got != tt.want || fmt.Sprint(names) != fmt.Sprint(tt.names) {
+			t.Errorf("%q: got %q %q, want %q %q", tt.query, got, names, tt.want, tt.names)
+		}
+	}
+
+	if hasNamed([]driver.NamedValue{{Ordinal: 1, Value: 1}}) || !hasNamed([]driver.NamedValue{{Name: "a", Value: 1}}) {
+		t.Error("hasNamed: wrong result")
+	}
+
+	values, err := bindNamed([]string{"a", "b", "a"}, []driver.NamedValue{{Name: "b", Value: 2}, {Name: "a", Value: 1}})
+	if err != nil || fmt.Sprint(values) != "[1 2 1]" {
+		t.Errorf("got %v, %v", values, err)
+	}
+	if _, err := bindNamed([]string{"a"}, []driver.NamedValue{{Ordinal: 1, Value: 1}}); err == nil {
+		t.Error("expected an error for a positional argument")
+	}
+	if _, err := bindNamed([]string{"a"}, []driver.NamedValue{{Name: "b", Value: 1}}); err == nil {
+		t.Error("expected an error for a missing argument")
+	}
+}
+
+func TestInsertTable(t *testing.T) {
+	for query, want := range map[string]string{
+		"insert into t values (1)":           "t",
+		"INSERT INTO s.t(a) values (1)":      "s.t",
+		"insert into \"T\" (a) values (1)":   "T",
+		"update t set a = 1":                 "",
+		"  insert  into `t`\n(a) values (1)": "t",
+	} {
+		if got := insertTable(query); got != want {
+			t.Errorf("%q: got %q, want %q", query, got, want)
+		}
+	}
+	if !isInsert("  Insert into t values (1)") || isInsert("select 1") {
+		t.Error("isInsert")
+	}
+}
@@ -0,0 +1,17 @@
+package driver
+
+import "context"
+
+type fetchSizeKey struct{}
+
+// WithFetchSize returns a context that makes queries run with it fetch
+// size rows per round trip to the driver instead of odbc.FETCH_SIZE.
+// A size of 1 fetches one row at a time.
+func WithFetchSize(ctx context.Context, size int) context.Context {
+	return context.WithValue(ctx, fetchSizeKey{}, size)
+}
+
+func fetchSize(ctx context.Context) int {
+	size, _ := ctx.Value(fetchSizeKey{}).(int)
+	return size
+}
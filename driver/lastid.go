@@ -0,0 +1,119 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
+)
+
+// LastInsertIdFunc returns the id generated by insert, which has just
+// run on c. It implements sql.Result.LastInsertId for a database system.
+type LastInsertIdFunc func(c *odbc.Connection, insert string) (int64, error)
+
+var errNoLastInsertId = errors.New("odbc: LastInsertId is not supported")
+
+// LastInsertIdQuery returns a LastInsertIdFunc that runs query, such
+// as "select last_insert_id()", and returns the first column.
+func LastInsertIdQuery(query string) LastInsertIdFunc {
+	return func(c *odbc.Connection, insert string) (int64, error) {
+		return queryInt64(c, query)
+	}
+}
+
+// SequenceCurrval returns a LastInsertIdFunc that reads the current
+// value of the sequence that sequence names for the table of the
+// insert, for databases that generate ids from sequences, such as
+// Oracle and Altibase:
+//
+//	cfg.LastInsertId = SequenceCurrval(func(table string) string {
+//		return table + "_seq"
+//	})
+func SequenceCurrval(sequence func(table string) string) LastInsertIdFunc {
+	return func(c *odbc.Connection, insert string) (int64, error) {
+		table := insertTable(insert)
+		if table == "" {
+			return 0, fmt.Errorf("odbc: no table name in %q", insert)
+		}
+		return queryInt64(c, "select "+sequence(table)+".currval from dual")
+	}
+}
+
+// DBMSLastInsertId is a LastInsertIdFunc chosen from SQL_DBMS_NAME,
+// which the driver knows from connecting: MySQL, SQLite and DB2 ask
+// for the last identity value, and SQL Server for @@IDENTITY, which is
+// the id generated by a trigger if the insert fired one. It returns
+// an error for other database systems.
+func DBMSLastInsertId(c *odbc.Connection, insert string) (int64, error) {
+	name, err := c.InfoString(api.SQL_DBMS_NAME)
+	if err != nil {
+		return 0, err
+	}
+	fn := dialectFor(name).lastInsertId
+	if fn == nil {
+		return 0, errNoLastInsertId
+	}
+	return fn(c, insert)
+}
+
+// lastInsertId returns the id generated by query if it is an insert,
+// computed right away since the connection may run other statements
+// before the result is looked at.
+func (c *conn) lastInsertId(query string) (int64, error) {
+	if c.lastInsertIdFunc == nil || !isInsert(query) {
+		return 0, errNoLastInsertId
+	}
+	return c.lastInsertIdFunc(c.c, query)
+}
+
+func isInsert(query string) bool {
+	q := strings.TrimSpace(query)
+	return len(q) >= 6 && strings.EqualFold(q[:6], "insert")
+}
+
+// insertTable returns the table name of "insert into <table> ...",
+// without quotes.
+func insertTable(insert string) string {
+	f := strings.Fields(insert)
+	if len(f) < 3 || !strings.EqualFold(f[0], "insert") || !strings.EqualFold(f[1], "into") {
+		return ""
+	}
+	table := f[2]
+	if i := strings.IndexByte(table, '('); i >= 0 {
+		table = table[:i]
+	}
+	return strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "").Replace(table)
+}
+
+func queryInt64(c *odbc.Connection, query string) (int64, error) {
+	st, err := c.ExecDirect(query)
+	if err != nil {
+		return 0, err
+	}
+	defer st.Close()
+	row, err := st.FetchOne()
+	if err != nil {
+		return 0, err
+	}
+	if row == nil || len(row.Data) == 0 {
+		return 0, fmt.Errorf("odbc: %q returned no rows", query)
+	}
+	switch v := row.Data[0].(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case nil:
+		return 0, fmt.Errorf("odbc: %q returned NULL", query)
+	}
+	return 0, fmt.Errorf("odbc: %q returned a %T", query, row.Data[0])
+}
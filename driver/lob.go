@@ -0,0 +1,74 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
+)
+
+type lobReadersKey struct{}
+
+// WithLOBReaders returns a context that makes queries run with it return
+// long binary and character columns (SQL_LONGVARBINARY, SQL_LONGVARCHAR,
+// SQL_WLONGVARCHAR) as *odbc.LOBReader instead of reading them into
+// memory. Scan them into an sql.Scanner that consumes the reader:
+//
+//	func (d *Document) Scan(src interface{}) error {
+//		r, ok := src.(io.Reader)
+//		if !ok {
+//			return fmt.Errorf("unexpected %T", src)
+//		}
+//		_, err := io.Copy(d.w, r)
+//		return err
+//	}
+//
+// Like sql.RawBytes, a reader is only valid until the next call to
+// Next. Long columns must come last in the select list and be scanned
+// in order, since drivers return the columns of a row front to back.
+func WithLOBReaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lobReadersKey{}, true)
+}
+
+func isLOB(ftype int) bool {
+	switch ftype {
+	case api.SQL_LONGVARBINARY, api.SQL_LONGVARCHAR, api.SQL_WLONGVARCHAR:
+		return true
+	}
+	return false
+}
+
+// nextLOBs fetches the next row, returning long columns as readers.
+func (r *rows) nextLOBs(dest []driver.Value, fields []*odbc.Field) error {
+	ok, err := r.s.st.Fetch()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return io.EOF
+	}
+	streaming := false
+	for i, f := range fields {
+		if isLOB(f.Type) {
+			rd, err := r.s.st.GetReader(i)
+			if err != nil {
+				return err
+			}
+			dest[i] = rd
+			streaming = true
+			continue
+		}
+		if streaming {
+			return fmt.Errorf("odbc: column %q follows a long column; move long columns to the end of the select list", f.Name)
+		}
+		v, _, _, err := r.s.st.GetField(i)
+		if err != nil {
+			return err
+		}
+		dest[i] = v
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// rewriteNamed replaces the :name and @name placeholders of query with
+// ? markers and returns the name of each marker in order, so a name
+// used twice is bound twice. String literals, quoted identifiers and
+// comments are left alone, as are "::" casts and "@@" system variables.
+// A query that already uses ? markers is returned unchanged with nil
+// names.
+//
+// Queries are only rewritten when they are run with sql.Named
+// arguments, or prepared with Config.NamedParams, since @name also
+// stands for MySQL user variables and T-SQL local variables.
+// Statements prepared without Config.NamedParams are rewritten on
+// their first execution with sql.Named arguments.
+func rewriteNamed(query string) (string, []string) {
+	var b strings.Builder
+	var names []string
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := quotedEnd(query, i)
+			b.WriteString(query[i:end])
+			i = end
+			continue
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			j := strings.IndexByte(query[i:], '\n')
+			if j < 0 {
+				j = len(query) - i
+			}
+			b.WriteString(query[i : i+j])
+			i += j
+			continue
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			j := strings.Index(query[i+2:], "*/")
+			end := len(query)
+			if j >= 0 {
+				end = i + 2 + j + 2
+			}
+			b.WriteString(query[i:end])
+			i = end
+			continue
+		case c == '?':
+			return query, nil
+		case c == ':' || c == '@':
+			if i+1 < len(query) && query[i+1] == c {
+				// a "::" cast or an "@@" system variable.
+				b.WriteString(query[i : i+2])
+				i += 2
+				continue
+			}
+			if (i == 0 || !isIdentByte(query[i-1])) && i+1 < len(query) && isIdentStart(query[i+1]) {
+				j := i + 1
+				for j < len(query) && isIdentByte(query[j]) {
+					j++
+				}
+				names = append(names, query[i+1:j])
+				b.WriteByte('?')
+				i = j
+				continue
+			}
+		}
+		b.WriteByte(c)
+		i++
+	}
+	if names == nil {
+		return query, nil
+	}
+	return b.String(), names
+}
+
+// quotedEnd returns the end of the string literal or quoted identifier
+// starting at query[i]. A doubled quote inside reads as two adjacent
+// quoted parts, and a backslash escapes the next character of a
+// string literal, as in MySQL.
+func quotedEnd(query string, i int) int {
+	q := query[i]
+	for j := i + 1; j < len(query); j++ {
+		switch query[j] {
+		case '\\':
+			if q != '`' {
+				j++
+			}
+		case q:
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+// hasNamed reports whether any of args is a sql.Named argument.
+func hasNamed(args []driver.NamedValue) bool {
+	for _, nv := range args {
+		if nv.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || '0' <= c && c <= '9'
+}
+
+// prepareNamed prepares the query of s again with its named
+// placeholders rewritten, the first time it is executed with
+// sql.Named arguments.
+func (s *stmt) prepareNamed() error {
+	query, names := rewriteNamed(s.query)
+	st, err := s.c.prepareStmt(query)
+	if err != nil {
+		return err
+	}
+	s.st.Close()
+	s.st, s.query, s.names, s.lazyNamed = st, query, names, false
+	return nil
+}
+
+// bindNamed orders args by the names of the markers of a rewritten query.
+func bindNamed(names []string, args []driver.NamedValue) ([]driver.Value, error) {
+	for _, nv := range args {
+		if nv.Name == "" {
+			return nil, errors.New("odbc: query uses named parameters, got a positional argument")
+		}
+	}
+	values := make([]driver.Value, len(names))
+	for i, name := range names {
+		found := false
+		for _, nv := range args {
+			if nv.Name == name {
+				values[i] = nv.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("odbc: missing argument for parameter %q", name)
+		}
+	}
+	return values, nil
+}
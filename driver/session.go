@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/jooita/sql/odbc"
+)
+
+// Ping checks the connection with a round trip to the server.
+func (c *conn) Ping(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
+	return c.ping(ctx)
+}
+
+// ping runs the validation query of the dialect.
+func (c *conn) ping(ctx context.Context) error {
+	ds, err := c.PrepareContext(ctx, c.getDialect().pingQuery)
+	if err != nil {
+		return badConn(err)
+	}
+	s := ds.(*stmt)
+	defer s.Close()
+	return badConn(s.execute(ctx, nil))
+}
+
+// IsValid reports whether the connection may be returned to the pool.
+// It only asks the driver whether it has lost the connection; drivers
+// without SQL_ATTR_CONNECTION_DEAD cannot tell.
+func (c *conn) IsValid() bool {
+	dead, err := c.c.Dead()
+	return err != nil || !dead
+}
+
+// ResetSession closes the cursors a previous user of the connection
+// left open, and undoes the changes of a transaction whose end could
+// not restore the session. It does not contact the server otherwise.
+func (c *conn) ResetSession(ctx context.Context) error {
+	for s := range c.stmts {
+		if err := s.st.CloseCursor(); err != nil {
+			return badConn(err)
+		}
+	}
+	if c.dirty != nil {
+		if err := c.dirty.end(); err != nil {
+			return badConn(err)
+		}
+	}
+	c.c.ClearWarnings()
+	return nil
+}
+
+// badConn turns connection failures into driver.ErrBadConn, so that
+// database/sql discards the connection and retries on another one.
+func badConn(err error) error {
+	if errors.Is(err, odbc.ErrConnection) {
+		return driver.ErrBadConn
+	}
+	return err
+}
@@ -5,12 +5,16 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"fmt"
 	"github.com/jooita/sql/api"
 	"github.com/jooita/sql/odbc"
 	"io"
+	"reflect"
+	"time"
 )
 
 func init() {
@@ -18,75 +22,346 @@ func init() {
 	sql.Register("odbc", d)
 }
 
-type Driver struct {
-	h api.SQLHENV
-}
+type Driver struct{}
 
 func (d *Driver) Open(dsn string) (driver.Conn, error) {
-	c, err := odbc.Connect(dsn)
+	c, err := d.OpenConnector(dsn)
 	if err != nil {
 		return nil, err
 	}
-	d.h = api.SQLHENV(odbc.Genv)
-	conn := &conn{c: c}
-	return conn, nil
+	return c.Connect(context.Background())
 }
 
-func (d *Driver) Close() error {
-	d.h = api.SQLHENV(api.SQL_NULL_HENV)
-	err := odbc.ReleaseHandle(d.h)
+// OpenConnector parses dsn with ParseConfig once for all the
+// connections of a sql.DB.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := ParseConfig(dsn)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &connector{cfg: *cfg, d: d}, nil
+}
+
+// Close is kept for compatibility. The ODBC environment is shared by
+// all connections and released when the process exits.
+func (d *Driver) Close() error {
 	return nil
 }
 
 type conn struct {
 	c *odbc.Connection
 	t *tx
+	// queryTimeout is set on every statement prepared on the connection.
+	queryTimeout time.Duration
+	// isolation is used by transactions begun with sql.LevelDefault.
+	isolation sql.IsolationLevel
+	cache     stmtCache
+
+	// stmts are the statements in use, whose cursors ResetSession closes.
+	stmts map[*stmt]bool
+	// autocommit is the mode the connection was opened with, which
+	// transactions return to when they end.
+	autocommit bool
+	// dirty is the transaction whose changes to the session are not
+	// undone yet, if restoring them failed; ResetSession tries again.
+	dirty   *tx
+	dialect *dialect
+	// namedParams rewrites the :name and @name placeholders of the
+	// queries passed to Prepare.
+	namedParams bool
+	// lastInsertIdFunc is Config.LastInsertId.
+	lastInsertIdFunc LastInsertIdFunc
 }
 
 func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	s, err := c.prepare(query, c.namedParams)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// prepare prepares query, with its named placeholders rewritten to ?
+// markers if named is set. Otherwise a query with named placeholders
+// is prepared as written, and again rewritten if it is executed with
+// sql.Named arguments.
+func (c *conn) prepare(query string, named bool) (*stmt, error) {
+	var names []string
+	lazyNamed := false
+	if named {
+		query, names = rewriteNamed(query)
+	} else if _, n := rewriteNamed(query); n != nil {
+		lazyNamed = true
+	}
+	st, err := c.prepareStmt(query)
+	if err != nil {
+		if lazyNamed {
+			return nil, fmt.Errorf("%w (set Config.NamedParams to prepare queries with :name or @name placeholders)", err)
+		}
+		return nil, err
+	}
+	s := c.newStmt(st, query, names)
+	s.lazyNamed = lazyNamed
+	return s, nil
+}
+
+// prepareStmt takes a statement prepared with query from the cache,
+// or prepares a new one.
+func (c *conn) prepareStmt(query string) (*odbc.Statement, error) {
+	if st := c.cache.get(query); st != nil {
+		return st, nil
+	}
 	st, err := c.c.Prepare(query)
 	if err != nil {
 		return nil, err
 	}
+	if c.queryTimeout > 0 {
+		if err := st.SetQueryTimeout(c.queryTimeout); err != nil {
+			st.Close()
+			return nil, err
+		}
+	}
+	return st, nil
+}
+
+func (c *conn) newStmt(st *odbc.Statement, query string, names []string) *stmt {
+	s := &stmt{st: st, c: c, query: query, names: names}
+	if c.stmts == nil {
+		c.stmts = make(map[*stmt]bool)
+	}
+	c.stmts[s] = true
+	return s
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Prepare(query)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, err := c.prepare(query, hasNamed(args))
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.QueryContext(ctx, args)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	// the statement is private to this query, so it goes away with the rows.
+	r.(*rows).closeStmt = true
+	return r, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, err := c.prepare(query, hasNamed(args))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return s.ExecContext(ctx, args)
+}
 
-	stmt := &stmt{st: st}
-	return stmt, nil
+// ExecBatch prepares query and executes it once for every row of the
+// column-wise parameter arrays in a single call. It is reached through
+// sql.Conn.Raw:
+//
+//	err := sqlConn.Raw(func(dc interface{}) error {
+//		b := dc.(interface {
+//			ExecBatch(string, [][]interface{}) (*odbc.BatchResult, error)
+//		})
+//		_, err := b.ExecBatch("insert into t values (?, ?)", columns)
+//		return err
+//	})
+func (c *conn) ExecBatch(query string, columns [][]interface{}) (*odbc.BatchResult, error) {
+	st, err := c.c.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+	return st.ExecuteBatch(columns)
 }
 
 func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.t != nil {
+		return nil, errors.New("odbc: transaction already in progress")
+	}
+
+	t := &tx{c: c}
+	c.dirty = t
+	isolation := sql.IsolationLevel(opts.Isolation)
+	if isolation == sql.LevelDefault {
+		isolation = c.isolation
+	}
+	if isolation != sql.LevelDefault {
+		level, err := isolationLevel(isolation)
+		if err != nil {
+			return nil, err
+		}
+		supported, err := c.c.SupportedIsolationLevels()
+		if err != nil {
+			return nil, err
+		}
+		if supported&level == 0 {
+			return nil, fmt.Errorf("odbc: isolation level %v is not supported by the driver", isolation)
+		}
+		prev, err := c.c.IsolationLevel()
+		if err != nil {
+			return nil, err
+		}
+		if prev != level {
+			if err := c.c.SetIsolationLevel(level); err != nil {
+				return nil, err
+			}
+			t.isolation = prev
+		}
+	}
+	if opts.ReadOnly {
+		// a connection opened with odbc.ReadOnlyMode stays read-only.
+		readOnly, err := c.c.ReadOnly()
+		if err != nil {
+			t.restore()
+			return nil, err
+		}
+		if !readOnly {
+			if err := c.c.SetReadOnly(true); err != nil {
+				t.restore()
+				return nil, err
+			}
+			t.readOnly = true
+		}
+	}
 	if err := c.c.AutoCommit(false); err != nil {
+		t.restore()
 		return nil, err
 	}
 
-	return &tx{c: c}, nil
+	c.t = t
+	return t, nil
+}
+
+func isolationLevel(level sql.IsolationLevel) (int, error) {
+	switch level {
+	case sql.LevelReadUncommitted:
+		return api.SQL_TXN_READ_UNCOMMITTED, nil
+	case sql.LevelReadCommitted:
+		return api.SQL_TXN_READ_COMMITTED, nil
+	case sql.LevelRepeatableRead:
+		return api.SQL_TXN_REPEATABLE_READ, nil
+	case sql.LevelSerializable:
+		return api.SQL_TXN_SERIALIZABLE, nil
+	}
+	return 0, fmt.Errorf("odbc: isolation level %v is not supported", level)
 }
 
 func (c *conn) Close() error {
+	c.cache.clear()
 	if c.c != nil {
 		return c.c.Close()
 	}
 	return nil
 }
 
+// dropDeletedStmts empties the statement cache if the driver deletes
+// prepared statements at the end of a transaction (SQL_CB_DELETE), as
+// reported by info, SQL_CURSOR_COMMIT_BEHAVIOR or _ROLLBACK_BEHAVIOR.
+func (c *conn) dropDeletedStmts(info int) {
+	if len(c.cache.idle) == 0 {
+		return
+	}
+	if cb, err := c.c.InfoUint16(info); err == nil && cb == api.SQL_CB_DELETE {
+		c.cache.clear()
+	}
+}
+
 type tx struct {
 	c *conn
+	// isolation is the level to restore when the transaction ends,
+	// or zero if BeginTx did not change it.
+	isolation int
+	// readOnly is set if BeginTx switched the connection to read-only.
+	readOnly bool
 }
 
 func (t *tx) Commit() error {
 	err := t.c.c.Commit()
+	t.c.dropDeletedStmts(api.SQL_CURSOR_COMMIT_BEHAVIOR)
+	if rerr := t.end(); err == nil {
+		err = rerr
+	}
 	return err
 }
 
 func (t *tx) Rollback() error {
 	err := t.c.c.Rollback()
+	t.c.dropDeletedStmts(api.SQL_CURSOR_ROLLBACK_BEHAVIOR)
+	if rerr := t.end(); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// end returns the connection to its autocommit mode and restores the
+// settings changed by BeginTx, so the pooled connection is reusable.
+func (t *tx) end() error {
+	t.c.t = nil
+	err := t.c.c.AutoCommit(t.c.autocommit)
+	if rerr := t.restore(); err == nil {
+		err = rerr
+	}
+	if err == nil && t.c.dirty == t {
+		t.c.dirty = nil
+	}
+	return err
+}
+
+// restore undoes the changes of BeginTx other than autocommit. What
+// fails to be restored is kept for the next try.
+func (t *tx) restore() error {
+	var err error
+	if t.readOnly {
+		if err = t.c.c.SetReadOnly(false); err == nil {
+			t.readOnly = false
+		}
+	}
+	if t.isolation != 0 {
+		if ierr := t.c.c.SetIsolationLevel(t.isolation); ierr != nil {
+			if err == nil {
+				err = ierr
+			}
+		} else {
+			t.isolation = 0
+		}
+	}
 	return err
 }
 
 type stmt struct {
 	st *odbc.Statement
+	// c and query return the statement to the cache of its connection.
+	c     *conn
+	query string
+	// names are the parameter names of the ? markers, if the query
+	// was written with named placeholders.
+	names []string
+	// lazyNamed is set if the query has named placeholders but was
+	// prepared as written, see prepareNamed.
+	lazyNamed bool
 }
 
 func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
@@ -95,7 +370,7 @@ func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
 	}
 
 	rowsAffected, err := s.st.RowsAffected()
-	r := &result{rowsAffected: int64(rowsAffected)}
+	r := &result{rowsAffected: int64(rowsAffected), lastInsertIdErr: errNoLastInsertId}
 	if err != nil {
 		return r, err
 	}
@@ -103,6 +378,10 @@ func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
 }
 
 func (s *stmt) NumInput() int {
+	if s.names != nil || s.lazyNamed {
+		// arguments are matched by name, and a name may be used twice.
+		return -1
+	}
 	return s.st.NumParams()
 }
 
@@ -115,16 +394,160 @@ func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
 }
 
 func (s *stmt) Close() error {
+	if s.c != nil {
+		delete(s.c.stmts, s)
+	}
+	// the next user of a cached statement must not reach the Out
+	// destinations and Stream readers of this one.
+	if s.c != nil && s.c.cache.size > 0 && s.st.CloseCursor() == nil && s.st.ResetParams() == nil {
+		s.c.cache.put(s.query, s.st)
+		return nil
+	}
 	s.st.Close()
 	return nil
 }
 
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	dargs, err := s.values(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.execute(ctx, dargs); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.st.RowsAffected()
+	r := &result{rowsAffected: int64(rowsAffected), lastInsertIdErr: errNoLastInsertId}
+	if err != nil {
+		return r, err
+	}
+	if s.c != nil {
+		r.lastInsertId, r.lastInsertIdErr = s.c.lastInsertId(s.query)
+	}
+	return r, nil
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	dargs, err := s.values(args)
+	if err != nil {
+		return nil, err
+	}
+	s.st.SetFetchSize(fetchSize(ctx))
+	if err := s.execute(ctx, dargs); err != nil {
+		return nil, err
+	}
+	rows := &rows{s: s, lobs: ctx.Value(lobReadersKey{}) != nil}
+	// a batch may start with row counts; skip to the first cursor.
+	if n, err := s.st.NumFields(); err == nil && n == 0 {
+		if _, err := rows.nextCursor(); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// execute runs the statement and cancels it through SQLCancel
+// if ctx is done before the driver returns.
+func (s *stmt) execute(ctx context.Context, args []driver.Value) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if ctx.Done() == nil {
+		return s.st.Execute2(args)
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		select {
+		case <-ctx.Done():
+			s.st.Cancel()
+		case <-done:
+		}
+	}()
+	err := s.st.Execute2(args)
+	close(done)
+	<-finished
+
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	return checkNamedValue(nv)
+}
+
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	return checkNamedValue(nv)
+}
+
+// checkNamedValue accepts the argument types the odbc package binds
+// itself and leaves everything else to the default converter.
+func checkNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case sql.Out:
+		nv.Value = odbc.Out{Dest: v.Dest, In: v.In}
+		return nil
+	case odbc.Stream, *odbc.Stream, odbc.TimeStamp, odbc.Date, odbc.Time:
+		return nil
+	case driver.Valuer:
+		return driver.ErrSkip
+	case io.Reader:
+		// stream readers to the driver instead of buffering them.
+		nv.Value = odbc.Stream{R: v, Length: -1}
+		return nil
+	}
+	// the default converter rejects uint64 values above MaxInt64,
+	// which the odbc package binds as they are.
+	switch v := reflect.ValueOf(nv.Value); v.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+	}
+	return driver.ErrSkip
+}
+
+// values orders args for the parameter markers of the statement.
+func (s *stmt) values(args []driver.NamedValue) ([]driver.Value, error) {
+	if s.lazyNamed && hasNamed(args) {
+		if err := s.prepareNamed(); err != nil {
+			return nil, err
+		}
+	}
+	if s.names != nil {
+		return bindNamed(s.names, args)
+	}
+	return namedValueToValue(args)
+}
+
+func namedValueToValue(named []driver.NamedValue) ([]driver.Value, error) {
+	args := make([]driver.Value, len(named))
+	for i, nv := range named {
+		if nv.Name != "" {
+			return nil, errors.New("odbc: driver does not support the use of Named Parameters")
+		}
+		args[i] = nv.Value
+	}
+	return args, nil
+}
+
 type result struct {
 	rowsAffected int64
+	// lastInsertId is looked up right after the insert, see conn.lastInsertId.
+	lastInsertId    int64
+	lastInsertIdErr error
 }
 
 func (r *result) LastInsertId() (int64, error) {
-	return 0, errors.New("not supported")
+	return r.lastInsertId, r.lastInsertIdErr
 }
 
 func (r *result) RowsAffected() (int64, error) {
@@ -133,29 +556,70 @@ func (r *result) RowsAffected() (int64, error) {
 
 type rows struct {
 	s *stmt
+	// closeStmt is set when the statement was prepared for this
+	// result set only and must be closed together with it.
+	closeStmt bool
+	fields    []*odbc.Field
+	// lobs returns long columns as readers, see WithLOBReaders.
+	lobs bool
+
+	// set by HasNextResultSet, which has to move to the next result
+	// to find out whether there is one.
+	advanced bool
+	hasNext  bool
+	nextErr  error
 }
 
 func (r *rows) Columns() []string {
-	c, err := r.s.st.NumFields()
+	fields, err := r.describe()
 	if err != nil {
 		return nil
 	}
-	columns := make([]string, c)
-	for i, _ := range columns {
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.Name
+	}
+	return columns
+}
+
+// describe returns the metadata of the current result set columns,
+// calling SQLDescribeCol only once per result set.
+func (r *rows) describe() ([]*odbc.Field, error) {
+	if r.fields != nil {
+		return r.fields, nil
+	}
+	c, err := r.s.st.NumFields()
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]*odbc.Field, c)
+	for i := range fields {
 		f, err := r.s.st.FieldMetadata(i + 1)
 		if err != nil {
-			return nil
+			return nil, err
 		}
-		columns[i] = f.Name
+		fields[i] = f
 	}
-	return columns
+	r.fields = fields
+	return fields, nil
 }
 
 func (r *rows) Close() error {
-	return r.s.Close()
+	if r.closeStmt {
+		return r.s.Close()
+	}
+	return r.s.st.CloseCursor()
 }
 
 func (r *rows) Next(dest []driver.Value) error {
+	if fields, err := r.describe(); err != nil {
+		return err
+	} else if len(fields) == 0 {
+		// the current result is a row count, not a cursor.
+		return io.EOF
+	} else if r.lobs {
+		return r.nextLOBs(dest, fields)
+	}
 	eof, err := r.s.st.FetchOne2(dest)
 	if err != nil {
 		return err
@@ -165,3 +629,44 @@ func (r *rows) Next(dest []driver.Value) error {
 	}
 	return nil
 }
+
+func (r *rows) HasNextResultSet() bool {
+	if !r.advanced {
+		r.hasNext, r.nextErr = r.nextCursor()
+		r.advanced = true
+	}
+	return r.hasNext || r.nextErr != nil
+}
+
+func (r *rows) NextResultSet() error {
+	if !r.advanced {
+		r.hasNext, r.nextErr = r.nextCursor()
+	}
+	r.advanced = false
+	if r.nextErr != nil {
+		return r.nextErr
+	}
+	if !r.hasNext {
+		return io.EOF
+	}
+	return nil
+}
+
+// nextCursor moves to the next result that returns rows, skipping
+// the row counts produced by INSERT, UPDATE and DELETE statements.
+func (r *rows) nextCursor() (bool, error) {
+	r.fields = nil
+	for {
+		ok, err := r.s.st.MoreResults()
+		if err != nil || !ok {
+			return false, err
+		}
+		n, err := r.s.st.NumFields()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+}
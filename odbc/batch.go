@@ -0,0 +1,284 @@
+package odbc
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// MAX_BATCH_SIZE limits the memory of the parameter arrays ExecuteBatch
+// binds at once, in bytes.
+const MAX_BATCH_SIZE = 64 * 1024 * 1024
+
+// BatchResult is the outcome of ExecuteBatch.
+type BatchResult struct {
+	// RowsAffected is the total number of rows changed by all parameter sets.
+	RowsAffected int
+	// Processed is the number of parameter sets the driver processed.
+	Processed int
+	// Status holds the SQL_PARAM_* status of every parameter set:
+	// api.SQL_PARAM_SUCCESS, SQL_PARAM_SUCCESS_WITH_INFO, SQL_PARAM_ERROR,
+	// SQL_PARAM_UNUSED or SQL_PARAM_DIAG_UNAVAILABLE.
+	Status []int
+}
+
+// Failed returns the indexes of the parameter sets that failed.
+func (r *BatchResult) Failed() []int {
+	var failed []int
+	for i, s := range r.Status {
+		if s == api.SQL_PARAM_ERROR {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// batchColumn is the array of values bound to one parameter marker.
+type batchColumn struct {
+	cType   api.SQLSMALLINT
+	sqlType api.SQLSMALLINT
+	size    api.SQLULEN
+	decimal api.SQLSMALLINT
+	width   int
+	params  []*inParam
+}
+
+// newBatchColumn converts the values of a parameter to a common C type,
+// wide enough for the longest value.
+func newBatchColumn(index int, values []interface{}) (*batchColumn, error) {
+	c := &batchColumn{params: make([]*inParam, len(values))}
+	typed := false
+	for i, v := range values {
+		p, err := newInParam(v)
+		if err != nil {
+			return nil, fmt.Errorf("odbc: parameter %d, row %d: %v", index, i, err)
+		}
+		c.params[i] = p
+		if v == nil {
+			continue
+		}
+		if !typed {
+			c.cType, c.sqlType = p.cType, p.sqlType
+			typed = true
+		} else if p.cType != c.cType {
+			return nil, fmt.Errorf("odbc: parameter %d mixes %T with values of another type at row %d", index, v, i)
+		}
+		if p.sqlType == api.SQL_WLONGVARCHAR || p.sqlType == api.SQL_LONGVARBINARY || p.sqlType == api.SQL_DECIMAL {
+			c.sqlType = p.sqlType
+		}
+		if p.size > c.size {
+			c.size = p.size
+		}
+		if p.decimal > c.decimal {
+			c.decimal = p.decimal
+		}
+		if len(p.buf) > c.width {
+			c.width = len(p.buf)
+		}
+	}
+	if !typed {
+		c.cType, c.sqlType, c.size = api.SQL_C_DEFAULT, api.SQL_VARCHAR, 1
+	}
+	if c.width == 0 {
+		c.width = 1
+	}
+	return c, nil
+}
+
+// ExecuteBatch executes the prepared statement once for every row of
+// the parameter arrays in a single call, using SQL_ATTR_PARAMSET_SIZE.
+// columns holds one slice of values per parameter marker, all of the
+// same length. The values of a parameter must share one Go type or be nil.
+// Arrays larger than MAX_BATCH_SIZE bytes are executed in chunks.
+//
+// If some parameter sets fail, the result reports their status along
+// with the error returned by the driver. The sets of the chunks after
+// a failed one are left SQL_PARAM_UNUSED.
+func (stmt *Statement) ExecuteBatch(columns [][]interface{}) (*BatchResult, error) {
+	var cParams api.SQLSMALLINT
+	ret := api.SQLNumParams(api.SQLHSTMT(stmt.handle), &cParams)
+	if IsError(ret) {
+		err := NewError("SQLNumParams", api.SQLHSTMT(stmt.handle))
+		return nil, err
+	}
+	if len(columns) != int(cParams) {
+		return nil, fmt.Errorf("odbc: statement expects %d parameters, got %d", cParams, len(columns))
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("odbc: ExecuteBatch needs at least one parameter")
+	}
+	rows := len(columns[0])
+	if rows == 0 {
+		return &BatchResult{}, nil
+	}
+
+	statusSize := int(unsafe.Sizeof(api.SQLUSMALLINT(0)))
+	indSize := int(unsafe.Sizeof(api.SQLLEN(0)))
+	cols := make([]*batchColumn, len(columns))
+	rowWidth := statusSize
+	for i, values := range columns {
+		if len(values) != rows {
+			return nil, fmt.Errorf("odbc: parameter %d has %d values, expected %d", i+1, len(values), rows)
+		}
+		c, err := newBatchColumn(i+1, values)
+		if err != nil {
+			return nil, err
+		}
+		if c.cType == api.SQL_C_DEFAULT {
+			if ft, _, _, _, err := stmt.GetParamType(i + 1); err == nil {
+				c.sqlType = api.SQLSMALLINT(ft)
+			}
+		}
+		c.width = (c.width + 7) &^ 7
+		cols[i] = c
+		rowWidth += c.width + indSize
+	}
+	chunk := batchChunk(rows, rowWidth)
+
+	// the status array, the processed count and every column live in
+	// one C allocation, which the driver writes to during SQLExecute.
+	// The chunks of a batch reuse it.
+	off := (chunk*statusSize + 7) &^ 7
+	total := off + 8
+	for _, c := range cols {
+		total += chunk * (c.width + indSize)
+	}
+	mem := C.calloc(1, C.size_t(total))
+	if mem == nil {
+		return nil, fmt.Errorf("odbc: cannot allocate %d bytes for a batch", total)
+	}
+	defer C.free(mem)
+	buf := unsafe.Slice((*byte)(mem), total)
+	status := unsafe.Slice((*api.SQLUSMALLINT)(mem), chunk)
+	processed := (*api.SQLULEN)(unsafe.Pointer(&buf[off]))
+	off += 8
+
+	h := api.SQLHSTMT(stmt.handle)
+	stmt.freeParams()
+	api.SQLFreeStmt(h, api.SQL_RESET_PARAMS)
+	defer stmt.resetBatch()
+
+	inds := make([][]api.SQLLEN, len(cols))
+	data := make([][]byte, len(cols))
+	for i, c := range cols {
+		inds[i] = unsafe.Slice((*api.SQLLEN)(unsafe.Pointer(&buf[off])), chunk)
+		off += chunk * indSize
+		data[i] = buf[off : off+chunk*c.width]
+		off += chunk * c.width
+		ret = api.SQLBindParameter(h, api.SQLUSMALLINT(i+1), api.SQL_PARAM_INPUT, c.cType, c.sqlType, c.size, c.decimal,
+			api.SQLPOINTER(unsafe.Pointer(&data[i][0])), api.SQLLEN(c.width), &inds[i][0])
+		if IsError(ret) {
+			err := NewError("SQLBindParameter", h)
+			return nil, err
+		}
+	}
+
+	for _, a := range []struct {
+		attr api.SQLINTEGER
+		v    uintptr
+	}{
+		{api.SQL_ATTR_PARAM_BIND_TYPE, api.SQL_PARAM_BIND_BY_COLUMN},
+		{api.SQL_ATTR_PARAM_STATUS_PTR, uintptr(mem)},
+		{api.SQL_ATTR_PARAMS_PROCESSED_PTR, uintptr(unsafe.Pointer(processed))},
+	} {
+		ret = api.SQLSetStmtUIntPtrAttr(h, a.attr, a.v, 0)
+		if IsError(ret) {
+			err := NewError("SQLSetStmtAttr", h)
+			return nil, err
+		}
+	}
+
+	stmt.resetFetch()
+	stmt.warnings.list = nil
+	r := &BatchResult{Status: make([]int, rows)}
+	for i := range r.Status {
+		r.Status[i] = api.SQL_PARAM_UNUSED
+	}
+	for first := 0; first < rows; first += chunk {
+		n := rows - first
+		if n > chunk {
+			n = chunk
+		}
+		for i, c := range cols {
+			for j, p := range c.params[first : first+n] {
+				copy(data[i][j*c.width:], p.buf)
+				inds[i][j] = p.ind
+			}
+		}
+		ret = api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_PARAMSET_SIZE, uintptr(n), 0)
+		if IsError(ret) {
+			err := NewError("SQLSetStmtAttr", h)
+			return r, err
+		}
+
+		nwarn := len(stmt.warnings.list)
+		ret = api.SQLExecute(h)
+		var err error
+		if ret != api.SQL_NO_DATA && IsError(ret) {
+			err = NewError("SQLExecute", h)
+		} else {
+			// the records of rows that failed come with SQL_SUCCESS_WITH_INFO.
+			err = stmt.warnings.check(ret, "SQLExecute", h)
+		}
+		// the driver numbers the rows of the chunk from 1.
+		offsetRows(stmt.warnings.list[nwarn:], first)
+		if e, ok := err.(*Error); ok {
+			offsetRows(e.Diag, first)
+		}
+
+		r.Processed += int(*processed)
+		for j, s := range status[:n] {
+			r.Status[first+j] = int(s)
+		}
+		if err != nil {
+			return r, err
+		}
+		var count api.SQLLEN
+		if !IsError(api.SQLRowCount(h, &count)) && count > 0 {
+			r.RowsAffected += int(count)
+		}
+	}
+	stmt.executed = true
+	return r, nil
+}
+
+// batchChunk returns the number of rows of a batch bound at once,
+// keeping the parameter arrays within MAX_BATCH_SIZE bytes.
+func batchChunk(rows, rowWidth int) int {
+	if rows*rowWidth <= MAX_BATCH_SIZE {
+		return rows
+	}
+	if n := MAX_BATCH_SIZE / rowWidth; n > 1 {
+		return n
+	}
+	return 1
+}
+
+// offsetRows adds n to the row numbers of recs.
+func offsetRows(recs []DiagRecord, n int) {
+	if n == 0 {
+		return
+	}
+	for i := range recs {
+		if recs[i].RowNumber > 0 {
+			recs[i].RowNumber += n
+		}
+	}
+}
+
+// resetBatch returns the statement to single parameter sets, so that
+// Execute can be used again.
+func (stmt *Statement) resetBatch() {
+	h := api.SQLHSTMT(stmt.handle)
+	api.SQLFreeStmt(h, api.SQL_RESET_PARAMS)
+	api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_PARAMSET_SIZE, 1, 0)
+	api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_PARAM_STATUS_PTR, 0, 0)
+	api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_PARAMS_PROCESSED_PTR, 0, 0)
+}
@@ -0,0 +1,90 @@
+package odbc
+
+// #include <stdlib.h>
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// paramBuffer is C memory holding a bound parameter value and its
+// length/indicator. The driver keeps the pointers passed to
+// SQLBindParameter and reads or writes them during SQLExecute, so
+// they must not point into Go memory that the collector may move or free.
+type paramBuffer struct {
+	mem unsafe.Pointer
+	buf []byte
+	ind *api.SQLLEN
+}
+
+// newParamBuffer allocates a zeroed buffer of size bytes.
+func newParamBuffer(size int) (*paramBuffer, error) {
+	var ind api.SQLLEN
+	n := C.size_t(unsafe.Sizeof(ind)) + C.size_t(size)
+	mem := C.calloc(1, n)
+	if mem == nil {
+		return nil, fmt.Errorf("odbc: cannot allocate %d bytes for a parameter", size)
+	}
+	b := &paramBuffer{mem: mem, ind: (*api.SQLLEN)(mem)}
+	if size > 0 {
+		b.buf = unsafe.Slice((*byte)(unsafe.Add(mem, unsafe.Sizeof(ind))), size)
+	}
+	return b, nil
+}
+
+// ptr returns the value pointer passed to SQLBindParameter.
+func (b *paramBuffer) ptr() api.SQLPOINTER {
+	if len(b.buf) == 0 {
+		return nil
+	}
+	return api.SQLPOINTER(unsafe.Pointer(&b.buf[0]))
+}
+
+func (b *paramBuffer) free() {
+	if b.mem != nil {
+		C.free(b.mem)
+		b.mem = nil
+		b.buf = nil
+		b.ind = nil
+	}
+}
+
+// setParamBuffer records b as the buffer bound to parameter index,
+// releasing the buffer of a previous binding.
+func (stmt *Statement) setParamBuffer(index int, b *paramBuffer) {
+	for len(stmt.params) < index {
+		stmt.params = append(stmt.params, nil)
+	}
+	if old := stmt.params[index-1]; old != nil {
+		old.free()
+	}
+	stmt.params[index-1] = b
+
+	for i, p := range stmt.outParams {
+		if p.index == index && p.paramBuffer != b {
+			stmt.outParams = append(stmt.outParams[:i], stmt.outParams[i+1:]...)
+			break
+		}
+	}
+	for i, p := range stmt.streams {
+		if p.index == index && p.paramBuffer != b {
+			stmt.streams = append(stmt.streams[:i], stmt.streams[i+1:]...)
+			break
+		}
+	}
+}
+
+// freeParams releases all parameter buffers. The statement must not be
+// executed again with the current bindings afterwards.
+func (stmt *Statement) freeParams() {
+	for _, b := range stmt.params {
+		if b != nil {
+			b.free()
+		}
+	}
+	stmt.params = nil
+	stmt.outParams = nil
+	stmt.streams = nil
+}
@@ -0,0 +1,441 @@
+package odbc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jooita/sql/api"
+)
+
+// The catalog functions below take their name arguments as ODBC
+// search patterns where the function accepts one ('%' and '_' are
+// wildcards). An empty string is passed as NULL and matches everything.
+
+// Table is a row of SQLTables.
+type Table struct {
+	Catalog string
+	Schema  string
+	Name    string
+	Type    string
+	Remarks string
+}
+
+// TableColumn is a row of SQLColumns.
+type TableColumn struct {
+	Catalog         string
+	Schema          string
+	Table           string
+	Name            string
+	DataType        int
+	TypeName        string
+	ColumnSize      int
+	BufferLength    int
+	DecimalDigits   int
+	NumPrecRadix    int
+	Nullable        int
+	Remarks         string
+	Default         string
+	CharOctetLength int
+	OrdinalPosition int
+	IsNullable      string
+}
+
+// PrimaryKey is a row of SQLPrimaryKeys.
+type PrimaryKey struct {
+	Catalog string
+	Schema  string
+	Table   string
+	Column  string
+	KeySeq  int
+	Name    string
+}
+
+// ForeignKey is a row of SQLForeignKeys: one column of a foreign key
+// and the primary key column it refers to.
+type ForeignKey struct {
+	PKCatalog     string
+	PKSchema      string
+	PKTable       string
+	PKColumn      string
+	FKCatalog     string
+	FKSchema      string
+	FKTable       string
+	FKColumn      string
+	KeySeq        int
+	UpdateRule    int
+	DeleteRule    int
+	FKName        string
+	PKName        string
+	Deferrability int
+}
+
+// IndexColumn is a row of SQLStatistics. The row with Type
+// SQL_TABLE_STAT describes the table itself and has no index name.
+type IndexColumn struct {
+	Catalog         string
+	Schema          string
+	Table           string
+	NonUnique       bool
+	IndexQualifier  string
+	IndexName       string
+	Type            int
+	OrdinalPosition int
+	Column          string
+	AscOrDesc       string
+	Cardinality     int
+	Pages           int
+	FilterCondition string
+}
+
+// SpecialColumn is a row of SQLSpecialColumns.
+type SpecialColumn struct {
+	Scope         int
+	Name          string
+	DataType      int
+	TypeName      string
+	ColumnSize    int
+	BufferLength  int
+	DecimalDigits int
+	PseudoColumn  int
+}
+
+// Procedure is a row of SQLProcedures.
+type Procedure struct {
+	Catalog string
+	Schema  string
+	Name    string
+	Remarks string
+	Type    int
+}
+
+// ProcedureColumn is a row of SQLProcedureColumns: a parameter,
+// the return value or a result set column of a procedure.
+type ProcedureColumn struct {
+	Catalog         string
+	Schema          string
+	Procedure       string
+	Name            string
+	ColumnType      int
+	DataType        int
+	TypeName        string
+	ColumnSize      int
+	BufferLength    int
+	DecimalDigits   int
+	NumPrecRadix    int
+	Nullable        int
+	Remarks         string
+	Default         string
+	CharOctetLength int
+	OrdinalPosition int
+	IsNullable      string
+}
+
+// Tables lists the tables matching the patterns. tableType is a comma
+// separated list such as "TABLE,VIEW", or empty for all types.
+func (conn *Connection) Tables(catalog, schema, table, tableType string) ([]Table, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	t, tl := catalogArg(table)
+	tt, ttl := catalogArg(tableType)
+	rows, err := conn.catalog("SQLTables", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLTables(h, c, cl, s, sl, t, tl, tt, ttl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	tables := make([]Table, len(rows))
+	for i, r := range rows {
+		tables[i] = Table{r.str(0), r.str(1), r.str(2), r.str(3), r.str(4)}
+	}
+	return tables, nil
+}
+
+// Columns lists the columns matching the patterns.
+func (conn *Connection) Columns(catalog, schema, table, column string) ([]TableColumn, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	t, tl := catalogArg(table)
+	col, coll := catalogArg(column)
+	rows, err := conn.catalog("SQLColumns", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLColumns(h, c, cl, s, sl, t, tl, col, coll)
+	})
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]TableColumn, len(rows))
+	for i, r := range rows {
+		columns[i] = TableColumn{
+			Catalog:         r.str(0),
+			Schema:          r.str(1),
+			Table:           r.str(2),
+			Name:            r.str(3),
+			DataType:        r.int(4),
+			TypeName:        r.str(5),
+			ColumnSize:      r.int(6),
+			BufferLength:    r.int(7),
+			DecimalDigits:   r.int(8),
+			NumPrecRadix:    r.int(9),
+			Nullable:        r.int(10),
+			Remarks:         r.str(11),
+			Default:         r.str(12),
+			CharOctetLength: r.int(15),
+			OrdinalPosition: r.int(16),
+			IsNullable:      r.str(17),
+		}
+	}
+	return columns, nil
+}
+
+// PrimaryKeys lists the primary key columns of a table.
+func (conn *Connection) PrimaryKeys(catalog, schema, table string) ([]PrimaryKey, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	t, tl := catalogArg(table)
+	rows, err := conn.catalog("SQLPrimaryKeys", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLPrimaryKeys(h, c, cl, s, sl, t, tl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]PrimaryKey, len(rows))
+	for i, r := range rows {
+		keys[i] = PrimaryKey{r.str(0), r.str(1), r.str(2), r.str(3), r.int(4), r.str(5)}
+	}
+	return keys, nil
+}
+
+// ForeignKeys lists the foreign keys of fkTable that refer to pkTable.
+// Leave the pk arguments empty to list all foreign keys of fkTable, or
+// the fk arguments empty to list all foreign keys referring to pkTable.
+func (conn *Connection) ForeignKeys(pkCatalog, pkSchema, pkTable, fkCatalog, fkSchema, fkTable string) ([]ForeignKey, error) {
+	pc, pcl := catalogArg(pkCatalog)
+	ps, psl := catalogArg(pkSchema)
+	pt, ptl := catalogArg(pkTable)
+	fc, fcl := catalogArg(fkCatalog)
+	fs, fsl := catalogArg(fkSchema)
+	ft, ftl := catalogArg(fkTable)
+	rows, err := conn.catalog("SQLForeignKeys", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLForeignKeys(h, pc, pcl, ps, psl, pt, ptl, fc, fcl, fs, fsl, ft, ftl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]ForeignKey, len(rows))
+	for i, r := range rows {
+		keys[i] = ForeignKey{
+			PKCatalog:     r.str(0),
+			PKSchema:      r.str(1),
+			PKTable:       r.str(2),
+			PKColumn:      r.str(3),
+			FKCatalog:     r.str(4),
+			FKSchema:      r.str(5),
+			FKTable:       r.str(6),
+			FKColumn:      r.str(7),
+			KeySeq:        r.int(8),
+			UpdateRule:    r.int(9),
+			DeleteRule:    r.int(10),
+			FKName:        r.str(11),
+			PKName:        r.str(12),
+			Deferrability: r.int(13),
+		}
+	}
+	return keys, nil
+}
+
+// Statistics lists the indexes of a table, column by column, and the
+// statistics of the table itself. If unique is set only unique indexes
+// are returned.
+func (conn *Connection) Statistics(catalog, schema, table string, unique bool) ([]IndexColumn, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	t, tl := catalogArg(table)
+	var u api.SQLUSMALLINT = api.SQL_INDEX_ALL
+	if unique {
+		u = api.SQL_INDEX_UNIQUE
+	}
+	rows, err := conn.catalog("SQLStatistics", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLStatistics(h, c, cl, s, sl, t, tl, u, api.SQL_QUICK)
+	})
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]IndexColumn, len(rows))
+	for i, r := range rows {
+		indexes[i] = IndexColumn{
+			Catalog:         r.str(0),
+			Schema:          r.str(1),
+			Table:           r.str(2),
+			NonUnique:       r.int(3) != 0,
+			IndexQualifier:  r.str(4),
+			IndexName:       r.str(5),
+			Type:            r.int(6),
+			OrdinalPosition: r.int(7),
+			Column:          r.str(8),
+			AscOrDesc:       r.str(9),
+			Cardinality:     r.int(10),
+			Pages:           r.int(11),
+			FilterCondition: r.str(12),
+		}
+	}
+	return indexes, nil
+}
+
+// SpecialColumns lists the columns that identify a row of a table
+// (identifierType api.SQL_BEST_ROWID) or that change whenever a row is
+// updated (api.SQL_ROWVER). scope is one of api.SQL_SCOPE_CURROW,
+// SQL_SCOPE_TRANSACTION or SQL_SCOPE_SESSION; nullable includes
+// columns that can be NULL.
+func (conn *Connection) SpecialColumns(identifierType int, catalog, schema, table string, scope int, nullable bool) ([]SpecialColumn, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	t, tl := catalogArg(table)
+	var n api.SQLUSMALLINT = api.SQL_NO_NULLS
+	if nullable {
+		n = api.SQL_NULLABLE
+	}
+	rows, err := conn.catalog("SQLSpecialColumns", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLSpecialColumns(h, api.SQLUSMALLINT(identifierType), c, cl, s, sl, t, tl, api.SQLUSMALLINT(scope), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]SpecialColumn, len(rows))
+	for i, r := range rows {
+		columns[i] = SpecialColumn{r.int(0), r.str(1), r.int(2), r.str(3), r.int(4), r.int(5), r.int(6), r.int(7)}
+	}
+	return columns, nil
+}
+
+// Procedures lists the procedures matching the patterns.
+func (conn *Connection) Procedures(catalog, schema, procedure string) ([]Procedure, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	p, pl := catalogArg(procedure)
+	rows, err := conn.catalog("SQLProcedures", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLProcedures(h, c, cl, s, sl, p, pl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	procedures := make([]Procedure, len(rows))
+	for i, r := range rows {
+		procedures[i] = Procedure{r.str(0), r.str(1), r.str(2), r.str(6), r.int(7)}
+	}
+	return procedures, nil
+}
+
+// ProcedureColumns lists the parameters and result columns of the
+// procedures matching the patterns.
+func (conn *Connection) ProcedureColumns(catalog, schema, procedure, column string) ([]ProcedureColumn, error) {
+	c, cl := catalogArg(catalog)
+	s, sl := catalogArg(schema)
+	p, pl := catalogArg(procedure)
+	col, coll := catalogArg(column)
+	rows, err := conn.catalog("SQLProcedureColumns", func(h api.SQLHSTMT) api.SQLRETURN {
+		return api.SQLProcedureColumns(h, c, cl, s, sl, p, pl, col, coll)
+	})
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]ProcedureColumn, len(rows))
+	for i, r := range rows {
+		columns[i] = ProcedureColumn{
+			Catalog:         r.str(0),
+			Schema:          r.str(1),
+			Procedure:       r.str(2),
+			Name:            r.str(3),
+			ColumnType:      r.int(4),
+			DataType:        r.int(5),
+			TypeName:        r.str(6),
+			ColumnSize:      r.int(7),
+			BufferLength:    r.int(8),
+			DecimalDigits:   r.int(9),
+			NumPrecRadix:    r.int(10),
+			Nullable:        r.int(11),
+			Remarks:         r.str(12),
+			Default:         r.str(13),
+			CharOctetLength: r.int(16),
+			OrdinalPosition: r.int(17),
+			IsNullable:      r.str(18),
+		}
+	}
+	return columns, nil
+}
+
+// catalogArg converts a catalog function argument, passing an empty
+// string as NULL.
+func catalogArg(s string) (*api.SQLWCHAR, api.SQLSMALLINT) {
+	if s == "" {
+		return nil, 0
+	}
+	w := StringToUTF16(s)
+	return (*api.SQLWCHAR)(&w[0]), api.SQLSMALLINT(len(w) - 1)
+}
+
+// catalogRow is a row of a catalog result set. Drivers of older ODBC
+// versions return fewer columns, which read as NULL.
+type catalogRow []interface{}
+
+// catalog runs a catalog function on a new statement and reads its result set.
+func (conn *Connection) catalog(apiName string, call func(api.SQLHSTMT) api.SQLRETURN) ([]catalogRow, error) {
+	stmt, err := conn.newStmt()
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	h := api.SQLHSTMT(stmt.handle)
+	if ret := call(h); IsError(ret) {
+		err := NewError(apiName, h)
+		return nil, err
+	}
+	stmt.executed = true
+
+	var rows []catalogRow
+	for {
+		row, err := stmt.FetchOne()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			return rows, nil
+		}
+		rows = append(rows, catalogRow(row.Data))
+	}
+}
+
+func (r catalogRow) str(i int) string {
+	if i >= len(r) {
+		return ""
+	}
+	switch v := r[i].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(r[i])
+}
+
+func (r catalogRow) int(i int) int {
+	if i >= len(r) {
+		return 0
+	}
+	switch v := r[i].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case float32:
+		return int(v)
+	case byte:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
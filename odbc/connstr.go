@@ -0,0 +1,213 @@
+package odbc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConnString is a connection string of Key=Value attributes separated
+// by ';', as taken by SQLDriverConnect. Keys are case-insensitive.
+// A value in braces may contain ';' and other special characters,
+// with "}}" standing for '}'.
+type ConnString struct {
+	attrs []connAttr
+}
+
+type connAttr struct {
+	key, value string
+}
+
+// connStringSpecial are the characters that make Encode put a value
+// in braces.
+const connStringSpecial = " ;{}[](),?*=!@"
+
+// ParseConnString parses s. When a key is repeated, the first value
+// is used, as by the driver manager.
+func ParseConnString(s string) (*ConnString, error) {
+	c := &ConnString{}
+	err := scanConnString(s, func(key, value string, start, end int) {
+		if _, ok := c.Get(key); !ok {
+			c.attrs = append(c.attrs, connAttr{key, value})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// RemoveConnAttrs returns s without the attributes for which remove
+// returns true. The other attributes are kept as written, so that a
+// value the driver manager reads differently from ParseConnString
+// reaches it unchanged.
+func RemoveConnAttrs(s string, remove func(key string) bool) (string, error) {
+	var b strings.Builder
+	last := 0
+	err := scanConnString(s, func(key, value string, start, end int) {
+		if remove(key) {
+			b.WriteString(s[last:start])
+			// drop the ';' that ends the attribute as well.
+			last = end + 1
+			if last > len(s) {
+				last = len(s)
+			}
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(s[last:])
+	return b.String(), nil
+}
+
+// scanConnString calls fn with every attribute of s and the byte
+// range s[start:end] it takes, without the ';' separator.
+func scanConnString(s string, fn func(key, value string, start, end int)) error {
+	for i := 0; i < len(s); {
+		start := i
+		end := strings.IndexAny(s[i:], "=;")
+		if end < 0 || s[i+end] == ';' {
+			// an attribute without '=' is empty or malformed.
+			if end < 0 {
+				end = len(s) - i
+			}
+			if a := strings.TrimSpace(s[i : i+end]); a != "" {
+				return fmt.Errorf("odbc: missing '=' after %q in connection string", a)
+			}
+			i += end + 1
+			continue
+		}
+		key := strings.TrimSpace(s[i : i+end])
+		i += end + 1
+
+		var value string
+		rest := strings.TrimLeft(s[i:], " ")
+		if strings.HasPrefix(rest, "{") {
+			i += len(s[i:]) - len(rest) + 1
+			var b strings.Builder
+			for {
+				j := strings.IndexByte(s[i:], '}')
+				if j < 0 {
+					return fmt.Errorf("odbc: unterminated '{' in value of %q", key)
+				}
+				b.WriteString(s[i : i+j])
+				i += j + 1
+				if i < len(s) && s[i] == '}' {
+					b.WriteByte('}')
+					i++
+					continue
+				}
+				break
+			}
+			value = b.String()
+			// skip to the end of the attribute.
+			j := strings.IndexByte(s[i:], ';')
+			if j < 0 {
+				j = len(s) - i
+			}
+			if extra := strings.TrimSpace(s[i : i+j]); extra != "" {
+				return fmt.Errorf("odbc: unexpected %q after value of %q", extra, key)
+			}
+			i += j
+		} else {
+			j := strings.IndexByte(s[i:], ';')
+			if j < 0 {
+				j = len(s) - i
+			}
+			value = strings.TrimSpace(s[i : i+j])
+			i += j
+		}
+		if key == "" {
+			return fmt.Errorf("odbc: empty key in connection string")
+		}
+		fn(key, value, start, i)
+		i++
+	}
+	return nil
+}
+
+// Get returns the value of key and whether it is set.
+func (c *ConnString) Get(key string) (string, bool) {
+	if i := c.index(key); i >= 0 {
+		return c.attrs[i].value, true
+	}
+	return "", false
+}
+
+// Set sets the value of key, keeping its position if it is already set.
+func (c *ConnString) Set(key, value string) {
+	if i := c.index(key); i >= 0 {
+		c.attrs[i].value = value
+		return
+	}
+	c.attrs = append(c.attrs, connAttr{key, value})
+}
+
+// Del removes key.
+func (c *ConnString) Del(key string) {
+	if i := c.index(key); i >= 0 {
+		c.attrs = append(c.attrs[:i], c.attrs[i+1:]...)
+	}
+}
+
+// Keys returns the keys in order.
+func (c *ConnString) Keys() []string {
+	keys := make([]string, len(c.attrs))
+	for i, a := range c.attrs {
+		keys[i] = a.key
+	}
+	return keys
+}
+
+// Encode returns the connection string to pass to SQLDriverConnect.
+func (c *ConnString) Encode() string {
+	return c.encode(false)
+}
+
+// String returns the connection string with passwords redacted.
+func (c *ConnString) String() string {
+	return c.encode(true)
+}
+
+func (c *ConnString) encode(redact bool) string {
+	var b strings.Builder
+	for i, a := range c.attrs {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(a.key)
+		b.WriteByte('=')
+		v := a.value
+		if redact && isPassword(a.key) {
+			v = "xxxxx"
+		}
+		if strings.ContainsAny(v, connStringSpecial) {
+			b.WriteByte('{')
+			b.WriteString(strings.Replace(v, "}", "}}", -1))
+			b.WriteByte('}')
+		} else {
+			b.WriteString(v)
+		}
+	}
+	return b.String()
+}
+
+func (c *ConnString) index(key string) int {
+	for i, a := range c.attrs {
+		if strings.EqualFold(a.key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isPassword(key string) bool {
+	return strings.EqualFold(key, "PWD") || strings.EqualFold(key, "Password")
+}
+
+// ConnString returns the completed connection string SQLDriverConnect
+// returned, with the attributes the driver added or defaulted. Use its
+// String method to log it without the password.
+func (conn *Connection) ConnString() (*ConnString, error) {
+	return ParseConnString(conn.completed)
+}
@@ -0,0 +1,54 @@
+package odbc
+
+import (
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// readFields reads the SQLGetDiagField fields of record rec. Fields
+// the driver does not report are left empty.
+func (r *DiagRecord) readFields(ht api.SQLSMALLINT, h api.SQLHANDLE, rec int) {
+	if ht == api.SQL_HANDLE_STMT {
+		// row and column numbers only exist for statements.
+		if n, ok := diagInt(ht, h, rec, api.SQL_DIAG_ROW_NUMBER); ok && n > 0 {
+			r.RowNumber = n
+		}
+		var col api.SQLINTEGER
+		ret := api.SQLGetDiagField(ht, h, api.SQLSMALLINT(rec), api.SQL_DIAG_COLUMN_NUMBER, api.SQLPOINTER(unsafe.Pointer(&col)), 0, nil)
+		if !IsError(ret) && col > 0 {
+			r.ColumnNumber = int(col)
+		}
+	}
+	r.ServerName = diagString(ht, h, rec, api.SQL_DIAG_SERVER_NAME)
+	r.ConnectionName = diagString(ht, h, rec, api.SQL_DIAG_CONNECTION_NAME)
+	r.ClassOrigin = diagString(ht, h, rec, api.SQL_DIAG_CLASS_ORIGIN)
+	r.SubclassOrigin = diagString(ht, h, rec, api.SQL_DIAG_SUBCLASS_ORIGIN)
+}
+
+// diagInt reads a SQLLEN diagnostic field.
+func diagInt(ht api.SQLSMALLINT, h api.SQLHANDLE, rec int, id api.SQLSMALLINT) (int, bool) {
+	var v api.SQLLEN
+	ret := api.SQLGetDiagField(ht, h, api.SQLSMALLINT(rec), id, api.SQLPOINTER(unsafe.Pointer(&v)), 0, nil)
+	if IsError(ret) {
+		return 0, false
+	}
+	return int(v), true
+}
+
+// diagString reads a character diagnostic field, or "" if it is unavailable.
+func diagString(ht api.SQLSMALLINT, h api.SQLHANDLE, rec int, id api.SQLSMALLINT) string {
+	buf := make([]uint16, INFO_BUFFER_LEN)
+	var n api.SQLSMALLINT
+	for {
+		ret := api.SQLGetDiagField(ht, h, api.SQLSMALLINT(rec), id, api.SQLPOINTER(unsafe.Pointer(&buf[0])), api.SQLSMALLINT(len(buf)*2), &n)
+		if IsError(ret) {
+			return ""
+		}
+		// n is the length in bytes without the null terminator.
+		if int(n)/2 < len(buf) || len(buf) == MAX_INFO_BUFFER_LEN {
+			return UTF16ToString(buf)
+		}
+		buf = make([]uint16, infoBufferLen(n))
+	}
+}
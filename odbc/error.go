@@ -2,7 +2,6 @@ package odbc
 
 import (
 	"github.com/jooita/sql/api"
-	"database/sql/driver"
 	"fmt"
 	"strings"
 	"unsafe"
@@ -16,6 +15,26 @@ type DiagRecord struct {
 	State       string
 	NativeError int
 	Message     string
+
+	// RowNumber and ColumnNumber locate the row of a parameter array or
+	// rowset, and the column or parameter, counting from 1. They are 0
+	// if the record is not about a row or column, or if it is unknown.
+	RowNumber    int
+	ColumnNumber int
+	// ServerName and ConnectionName identify the connection.
+	ServerName     string
+	ConnectionName string
+	// ClassOrigin and SubclassOrigin name the standard defining the
+	// class and subclass of State, such as "ISO 9075" or "ODBC 3.0".
+	ClassOrigin    string
+	SubclassOrigin string
+
+	// DynamicFunction and CursorRowCount come from the header of the
+	// diagnostics, shared by all records of a call: the kind of SQL
+	// statement executed, such as "INSERT", and the number of rows in
+	// its cursor, or -1 if unavailable.
+	DynamicFunction string
+	CursorRowCount  int
 }
 
 func (r *DiagRecord) String() string {
@@ -40,7 +59,16 @@ func NewError(apiName string, handle interface{}) error {
 	if herr != nil {
 		return herr
 	}
-	err := &Error{APIName: apiName}
+	recs, err := diagRecords(ht, h)
+	if err != nil {
+		return err
+	}
+	return &Error{APIName: apiName, Diag: recs}
+}
+
+// diagRecords reads the diagnostic records of the last call on h.
+func diagRecords(ht api.SQLSMALLINT, h api.SQLHANDLE) ([]DiagRecord, error) {
+	var recs []DiagRecord
 	var ne api.SQLINTEGER
 	state := make([]uint16, 6)
 	msg := make([]uint16, api.SQL_MAX_MESSAGE_LENGTH)
@@ -53,17 +81,28 @@ func NewError(apiName string, handle interface{}) error {
 			break
 		}
 		if IsError(ret) {
-			return fmt.Errorf("SQLGetDiagRec failed: ret=%d", ret)
+			return nil, fmt.Errorf("SQLGetDiagRec failed: ret=%d", ret)
 		}
 		r := DiagRecord{
 			State:       UTF16ToString(state),
 			NativeError: int(ne),
 			Message:     UTF16ToString(msg),
 		}
-		if r.State == "08S01" {
-			return driver.ErrBadConn
+		r.readFields(ht, h, i)
+		recs = append(recs, r)
+	}
+	if len(recs) > 0 {
+		fn := diagString(ht, h, 0, api.SQL_DIAG_DYNAMIC_FUNCTION)
+		count := -1
+		if ht == api.SQL_HANDLE_STMT {
+			if n, ok := diagInt(ht, h, 0, api.SQL_DIAG_CURSOR_ROW_COUNT); ok {
+				count = n
+			}
+		}
+		for i := range recs {
+			recs[i].DynamicFunction = fn
+			recs[i].CursorRowCount = count
 		}
-		err.Diag = append(err.Diag, r)
 	}
-	return err
+	return recs, nil
 }
@@ -0,0 +1,317 @@
+package odbc
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+const (
+	// FETCH_SIZE is the default number of rows FetchOne, FetchOne2 and
+	// FetchAll get from the driver with each SQLFetch.
+	FETCH_SIZE = 256
+	// MAX_BOUND_COLUMN_SIZE is the widest column bound to a row array.
+	// Result sets with wider or long columns are fetched row by row.
+	MAX_BOUND_COLUMN_SIZE = 32 * 1024
+	// MAX_ROW_ARRAY_SIZE limits the memory of a row array in bytes.
+	MAX_ROW_ARRAY_SIZE = 4 * 1024 * 1024
+)
+
+// column is the type of a result set column, described once per result set.
+type column struct {
+	ftype int
+	size  int
+	// buf is the SQLGetData buffer of the column, see getDataBuffer.
+	buf []byte
+}
+
+// boundColumn is a column bound to a row array with SQLBindCol.
+type boundColumn struct {
+	cType api.SQLSMALLINT
+	width int
+	ind   []api.SQLLEN
+	data  []byte
+}
+
+// rowArray is a block cursor: SQLFetch fills the bound buffers with up
+// to size rows at once (SQL_ATTR_ROW_ARRAY_SIZE), and the rows are
+// then returned one by one from memory.
+type rowArray struct {
+	mem     unsafe.Pointer
+	fetched *api.SQLULEN
+	cols    []boundColumn
+	n       int
+	pos     int
+	eof     bool
+}
+
+// SetFetchSize sets the number of rows fetched at once by FetchOne,
+// FetchOne2 and FetchAll. A size of 1 fetches one row at a time, and
+// a size of 0 restores FETCH_SIZE. It applies from the next result set.
+func (stmt *Statement) SetFetchSize(size int) {
+	stmt.fetchSize = size
+}
+
+func (stmt *Statement) columns() ([]column, error) {
+	if stmt.cols != nil {
+		return stmt.cols, nil
+	}
+	n, err := stmt.NumFields()
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]column, n)
+	for i := range cols {
+		if cols[i].ftype, err = stmt.ColAttributeInt(i+1, api.SQL_DESC_CONCISE_TYPE); err != nil {
+			return nil, err
+		}
+		if cols[i].size, err = stmt.ColAttributeInt(i+1, api.SQL_DESC_LENGTH); err != nil {
+			return nil, err
+		}
+	}
+	stmt.cols = cols
+	return cols, nil
+}
+
+// boundType returns the C type and the buffer width a column is
+// bound with, or false if it has to be read with SQLGetData.
+func boundType(c column) (api.SQLSMALLINT, int, bool) {
+	var cType api.SQLSMALLINT
+	var width int
+	switch c.ftype {
+	case api.SQL_BIT:
+		cType, width = api.SQL_C_BIT, 1
+	case api.SQL_INTEGER, api.SQL_SMALLINT, api.SQL_TINYINT:
+		cType, width = api.SQL_C_LONG, 4
+	case api.SQL_BIGINT:
+		cType, width = api.SQL_C_SBIGINT, 8
+	case api.SQL_REAL:
+		cType, width = api.SQL_C_FLOAT, 4
+	case api.SQL_FLOAT, api.SQL_DOUBLE, api.SQL_NUMERIC, api.SQL_DECIMAL:
+		cType, width = api.SQL_C_DOUBLE, 8
+	case api.SQL_TYPE_TIMESTAMP, api.SQL_TYPE_DATE, api.SQL_TYPE_TIME, api.SQL_DATETIME:
+		var v api.SQL_TIMESTAMP_STRUCT
+		cType, width = api.SQL_C_TYPE_TIMESTAMP, int(unsafe.Sizeof(v))
+	case api.SQL_WCHAR, api.SQL_WVARCHAR:
+		// room for surrogate pairs and the null terminator.
+		cType, width = api.SQL_C_WCHAR, (c.size*2+1)*2
+	case api.SQL_CHAR, api.SQL_VARCHAR:
+		// room for 4 byte UTF-8 characters and the null terminator.
+		cType, width = api.SQL_C_CHAR, c.size*4+1
+	case api.SQL_BINARY, api.SQL_VARBINARY:
+		cType, width = api.SQL_C_BINARY, c.size
+	default:
+		return 0, 0, false
+	}
+	if c.size <= 0 || width > MAX_BOUND_COLUMN_SIZE {
+		return 0, 0, false
+	}
+	return cType, width, true
+}
+
+// bindRowArray binds the columns of the current result set to a row
+// array. It returns nil if the result set has to be fetched row by row.
+func (stmt *Statement) bindRowArray() (*rowArray, error) {
+	size := stmt.fetchSize
+	if size == 0 {
+		size = FETCH_SIZE
+	}
+	// scrollable cursors are positioned one row at a time.
+	if size <= 1 || stmt.scrollable {
+		return nil, nil
+	}
+	cols, err := stmt.columns()
+	if err != nil || len(cols) == 0 {
+		return nil, err
+	}
+
+	ra := &rowArray{cols: make([]boundColumn, len(cols))}
+	indSize := int(unsafe.Sizeof(api.SQLLEN(0)))
+	rowWidth := 0
+	for i, c := range cols {
+		cType, width, ok := boundType(c)
+		if !ok {
+			return nil, nil
+		}
+		// keep every data array aligned for the C types.
+		width = (width + 7) &^ 7
+		ra.cols[i] = boundColumn{cType: cType, width: width}
+		rowWidth += width + indSize
+	}
+	if size*rowWidth > MAX_ROW_ARRAY_SIZE {
+		size = MAX_ROW_ARRAY_SIZE / rowWidth
+		if size <= 1 {
+			return nil, nil
+		}
+	}
+
+	total := 8 + size*rowWidth
+	ra.mem = C.calloc(1, C.size_t(total))
+	if ra.mem == nil {
+		return nil, fmt.Errorf("odbc: cannot allocate %d bytes for a row array", total)
+	}
+	mem := unsafe.Slice((*byte)(ra.mem), total)
+	ra.fetched = (*api.SQLULEN)(ra.mem)
+	off := 8
+	for i := range ra.cols {
+		c := &ra.cols[i]
+		c.ind = unsafe.Slice((*api.SQLLEN)(unsafe.Pointer(&mem[off])), size)
+		off += size * indSize
+		c.data = mem[off : off+size*c.width]
+		off += size * c.width
+	}
+
+	h := api.SQLHSTMT(stmt.handle)
+	stmt.rowArray = ra
+	ret := api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_ROW_BIND_TYPE, api.SQL_BIND_BY_COLUMN, 0)
+	if !IsError(ret) {
+		ret = api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_ROW_ARRAY_SIZE, uintptr(size), 0)
+	}
+	if IsError(ret) {
+		// the driver has no block cursors; fetch row by row instead.
+		stmt.unbindRowArray()
+		return nil, nil
+	}
+	ret = api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_ROWS_FETCHED_PTR, uintptr(ra.mem), 0)
+	if IsError(ret) {
+		err := NewError("SQLSetStmtAttr", h)
+		stmt.unbindRowArray()
+		return nil, err
+	}
+	for i, c := range ra.cols {
+		ret = api.SQLBindCol(h, api.SQLUSMALLINT(i+1), c.cType, api.SQLPOINTER(unsafe.Pointer(&c.data[0])), api.SQLLEN(c.width), &c.ind[0])
+		if IsError(ret) {
+			err := NewError("SQLBindCol", h)
+			stmt.unbindRowArray()
+			return nil, err
+		}
+	}
+	ra.pos = -1
+	return ra, nil
+}
+
+// unbindRowArray returns the statement to single row fetches and
+// releases the row array.
+func (stmt *Statement) unbindRowArray() {
+	ra := stmt.rowArray
+	if ra == nil {
+		return
+	}
+	if stmt.handle != api.SQLHANDLE(api.SQL_NULL_HANDLE) {
+		h := api.SQLHSTMT(stmt.handle)
+		api.SQLFreeStmt(h, api.SQL_UNBIND)
+		api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_ROW_ARRAY_SIZE, 1, 0)
+		api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_ROWS_FETCHED_PTR, 0, 0)
+	}
+	C.free(ra.mem)
+	stmt.rowArray = nil
+}
+
+// resetFetch forgets the columns of the previous result set.
+func (stmt *Statement) resetFetch() {
+	stmt.unbindRowArray()
+	stmt.cols = nil
+	stmt.rowByRow = false
+}
+
+// fetchRow moves to the next row, through a row array if the
+// columns of the result set can be bound.
+func (stmt *Statement) fetchRow() (bool, error) {
+	if stmt.rowArray == nil && !stmt.rowByRow {
+		// FetchOne and FetchOne2 read stmt.cols, whichever way the rows are fetched.
+		if _, err := stmt.columns(); err != nil {
+			return false, err
+		}
+		ra, err := stmt.bindRowArray()
+		if err != nil {
+			return false, err
+		}
+		stmt.rowByRow = ra == nil
+	}
+	ra := stmt.rowArray
+	if ra == nil {
+		return stmt.Fetch()
+	}
+
+	ra.pos++
+	if ra.pos < ra.n {
+		return true, nil
+	}
+	if ra.eof {
+		return false, nil
+	}
+	ok, err := stmt.Fetch()
+	if err != nil {
+		return false, err
+	}
+	ra.pos, ra.n = 0, int(*ra.fetched)
+	if !ok || ra.n == 0 {
+		ra.eof = true
+		ra.n = 0
+		return false, nil
+	}
+	return true, nil
+}
+
+// field returns the column field_index of the row fetchRow moved to.
+func (stmt *Statement) field(field_index int) (interface{}, error) {
+	if ra := stmt.rowArray; ra != nil {
+		return ra.cols[field_index].value(field_index, ra.pos)
+	}
+	v, _, _, err := stmt.GetField(field_index)
+	return v, err
+}
+
+func (c *boundColumn) value(field_index, row int) (interface{}, error) {
+	ind := c.ind[row]
+	if ind == api.SQL_NULL_DATA {
+		return nil, nil
+	}
+	b := c.data[row*c.width : (row+1)*c.width]
+	p := unsafe.Pointer(&b[0])
+	switch c.cType {
+	case api.SQL_C_BIT:
+		return b[0], nil
+	case api.SQL_C_LONG:
+		return int(*(*int32)(p)), nil
+	case api.SQL_C_SBIGINT:
+		return *(*int64)(p), nil
+	case api.SQL_C_FLOAT:
+		return *(*float32)(p), nil
+	case api.SQL_C_DOUBLE:
+		return *(*float64)(p), nil
+	case api.SQL_C_TYPE_TIMESTAMP:
+		v := (*api.SQL_TIMESTAMP_STRUCT)(p)
+		return time.Date(int(v.Year), time.Month(v.Month), int(v.Day), int(v.Hour), int(v.Minute), int(v.Second), int(v.Fraction), time.UTC), nil
+	}
+
+	// character data is null-terminated within the buffer.
+	limit := c.width
+	switch c.cType {
+	case api.SQL_C_WCHAR:
+		limit -= 2
+	case api.SQL_C_CHAR:
+		limit--
+	}
+	n := int(ind)
+	if ind == api.SQL_NO_TOTAL || n > limit {
+		return nil, fmt.Errorf("odbc: value of column %d does not fit its %d byte buffer", field_index+1, c.width)
+	}
+	switch c.cType {
+	case api.SQL_C_WCHAR:
+		u := unsafe.Slice((*uint16)(p), n/2)
+		return string(utf16.Decode(u)), nil
+	case api.SQL_C_CHAR:
+		return string(b[:n]), nil
+	}
+	// the buffer is reused by the next fetch.
+	return append([]byte(nil), b[:n]...), nil
+}
@@ -0,0 +1,154 @@
+package odbc
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// Bitmask is an SQLGetInfo value made of SQL_* flags, such as the
+// SQL_SO_* bits of SQL_SCROLL_OPTIONS.
+type Bitmask uint32
+
+// Has reports whether all bits of flags are set.
+func (b Bitmask) Has(flags uint32) bool {
+	return uint32(b)&flags == flags
+}
+
+type infoKind int
+
+const (
+	infoString infoKind = iota
+	infoUint16
+	infoUint32
+	infoBitmask
+)
+
+// infoKinds gives the value type of the info types Info knows about.
+var infoKinds = map[int]infoKind{
+	api.SQL_DATA_SOURCE_NAME:      infoString,
+	api.SQL_DATA_SOURCE_READ_ONLY: infoString,
+	api.SQL_DATABASE_NAME:         infoString,
+	api.SQL_DBMS_NAME:             infoString,
+	api.SQL_DBMS_VER:              infoString,
+	api.SQL_DRIVER_NAME:           infoString,
+	api.SQL_DRIVER_ODBC_VER:       infoString,
+	api.SQL_DRIVER_VER:            infoString,
+	api.SQL_IDENTIFIER_QUOTE_CHAR: infoString,
+	api.SQL_ODBC_VER:              infoString,
+	api.SQL_SEARCH_PATTERN_ESCAPE: infoString,
+	api.SQL_SERVER_NAME:           infoString,
+	api.SQL_USER_NAME:             infoString,
+
+	api.SQL_CURSOR_COMMIT_BEHAVIOR:    infoUint16,
+	api.SQL_CURSOR_ROLLBACK_BEHAVIOR:  infoUint16,
+	api.SQL_MAX_CATALOG_NAME_LEN:      infoUint16,
+	api.SQL_MAX_COLUMN_NAME_LEN:       infoUint16,
+	api.SQL_MAX_CONCURRENT_ACTIVITIES: infoUint16,
+	api.SQL_MAX_SCHEMA_NAME_LEN:       infoUint16,
+	api.SQL_MAX_TABLE_NAME_LEN:        infoUint16,
+	api.SQL_TXN_CAPABLE:               infoUint16,
+
+	api.SQL_DEFAULT_TXN_ISOLATION:  infoUint32,
+	api.SQL_PARAM_ARRAY_ROW_COUNTS: infoUint32,
+
+	api.SQL_BATCH_ROW_COUNT:            infoBitmask,
+	api.SQL_BATCH_SUPPORT:              infoBitmask,
+	api.SQL_DYNAMIC_CURSOR_ATTRIBUTES1: infoBitmask,
+	api.SQL_GETDATA_EXTENSIONS:         infoBitmask,
+	api.SQL_KEYSET_CURSOR_ATTRIBUTES1:  infoBitmask,
+	api.SQL_SCROLL_OPTIONS:             infoBitmask,
+	api.SQL_STATIC_CURSOR_ATTRIBUTES1:  infoBitmask,
+	api.SQL_TXN_ISOLATION_OPTION:       infoBitmask,
+}
+
+// Info returns the SQLGetInfo value of infoType as a string, uint16,
+// uint32 or Bitmask. It only knows the info types defined in the api
+// package; use InfoString, InfoUint16, InfoUint32 or InfoBitmask for others.
+func (conn *Connection) Info(infoType int) (interface{}, error) {
+	kind, ok := infoKinds[infoType]
+	if !ok {
+		return nil, fmt.Errorf("odbc: unknown info type %d", infoType)
+	}
+	switch kind {
+	case infoString:
+		return conn.InfoString(infoType)
+	case infoUint16:
+		return conn.InfoUint16(infoType)
+	case infoUint32:
+		return conn.InfoUint32(infoType)
+	}
+	return conn.InfoBitmask(infoType)
+}
+
+// InfoString returns a character string SQLGetInfo value.
+func (conn *Connection) InfoString(infoType int) (string, error) {
+	buf := make([]uint16, INFO_BUFFER_LEN)
+	for {
+		var n api.SQLSMALLINT
+		ret := api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQLUSMALLINT(infoType), api.SQLPOINTER(unsafe.Pointer(&buf[0])), api.SQLSMALLINT(len(buf)*2), &n)
+		if IsError(ret) {
+			err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
+			return "", err
+		}
+		// n is the full length in bytes; retry if it was truncated.
+		if int(n)/2 < len(buf) || len(buf) == MAX_INFO_BUFFER_LEN {
+			return UTF16ToString(buf), nil
+		}
+		buf = make([]uint16, infoBufferLen(n))
+	}
+}
+
+// MAX_INFO_BUFFER_LEN is the longest buffer, in UTF-16 units, whose
+// length in bytes fits the SQLSMALLINT buffer length of SQLGetInfo
+// and SQLGetDiagField.
+const MAX_INFO_BUFFER_LEN = (1<<15 - 1) / 2
+
+// infoBufferLen returns the buffer length for a value of n bytes.
+func infoBufferLen(n api.SQLSMALLINT) int {
+	if l := int(n)/2 + 1; l > 0 && l < MAX_INFO_BUFFER_LEN {
+		return l
+	}
+	return MAX_INFO_BUFFER_LEN
+}
+
+// InfoUint16 returns an SQLUSMALLINT SQLGetInfo value.
+func (conn *Connection) InfoUint16(infoType int) (uint16, error) {
+	var v api.SQLUSMALLINT
+	ret := api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQLUSMALLINT(infoType), api.SQLPOINTER(unsafe.Pointer(&v)), 0, nil)
+	if IsError(ret) {
+		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+// InfoUint32 returns an SQLUINTEGER SQLGetInfo value.
+func (conn *Connection) InfoUint32(infoType int) (uint32, error) {
+	var v api.SQLUINTEGER
+	ret := api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQLUSMALLINT(infoType), api.SQLPOINTER(unsafe.Pointer(&v)), 0, nil)
+	if IsError(ret) {
+		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
+		return 0, err
+	}
+	return uint32(v), nil
+}
+
+// InfoBitmask returns an SQLUINTEGER bitmask SQLGetInfo value.
+func (conn *Connection) InfoBitmask(infoType int) (Bitmask, error) {
+	v, err := conn.InfoUint32(infoType)
+	return Bitmask(v), err
+}
+
+// SupportsFunction reports whether the driver implements the ODBC
+// function identified by an api.SQL_API_* constant, using SQLGetFunctions.
+func (conn *Connection) SupportsFunction(functionId int) (bool, error) {
+	var supported api.SQLUSMALLINT
+	ret := api.SQLGetFunctions(api.SQLHDBC(conn.Dbc), api.SQLUSMALLINT(functionId), &supported)
+	if IsError(ret) {
+		err := NewError("SQLGetFunctions", api.SQLHDBC(conn.Dbc))
+		return false, err
+	}
+	return supported != 0, nil
+}
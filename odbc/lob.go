@@ -0,0 +1,173 @@
+package odbc
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// GET_DATA_CHUNK_SIZE is the size of the buffer passed to SQLGetData
+// when a column is read in chunks.
+const GET_DATA_CHUNK_SIZE = 64 * 1024
+
+// LOBReader reads one column of the current row in chunks with
+// repeated SQLGetData calls, so long values never have to be held in
+// memory at once. Character columns are returned as UTF-8.
+//
+// A LOBReader is valid until the next fetch or until the cursor is
+// closed. Most drivers require the columns of a row to be read in
+// increasing order, so reading a column with a lower index after the
+// reader was created makes it fail.
+type LOBReader struct {
+	stmt  *Statement
+	col   int
+	cType api.SQLSMALLINT
+
+	buf     []byte
+	pending []byte
+	high    uint16 // leading half of a surrogate pair split across chunks
+	null    bool
+	err     error
+}
+
+// GetReader returns a reader for the column field_index (0-based, as in
+// GetField) of the current row.
+func (stmt *Statement) GetReader(field_index int) (*LOBReader, error) {
+	cols, err := stmt.columns()
+	if err != nil {
+		return nil, err
+	}
+	return stmt.newLOBReader(field_index, lobCType(cols[field_index].ftype), make([]byte, GET_DATA_CHUNK_SIZE)), nil
+}
+
+func lobCType(ftype int) api.SQLSMALLINT {
+	switch ftype {
+	case api.SQL_WCHAR, api.SQL_WVARCHAR, api.SQL_WLONGVARCHAR:
+		return api.SQL_C_WCHAR
+	case api.SQL_CHAR, api.SQL_VARCHAR, api.SQL_LONGVARCHAR:
+		return api.SQL_C_CHAR
+	}
+	return api.SQL_C_BINARY
+}
+
+func (stmt *Statement) newLOBReader(field_index int, cType api.SQLSMALLINT, buf []byte) *LOBReader {
+	return &LOBReader{stmt: stmt, col: field_index + 1, cType: cType, buf: buf}
+}
+
+// getDataBuffer returns the buffer readAll passes to SQLGetData for
+// the column field_index. size is the longest value of the column,
+// including the null terminator, or 0 if it is unknown. The buffer of
+// a column is reused for every row of the result set.
+func (stmt *Statement) getDataBuffer(field_index int, size int) []byte {
+	if size <= 0 || size > GET_DATA_CHUNK_SIZE {
+		size = GET_DATA_CHUNK_SIZE
+	}
+	if field_index < 0 || field_index >= len(stmt.cols) {
+		return make([]byte, size)
+	}
+	c := &stmt.cols[field_index]
+	if len(c.buf) != size {
+		c.buf = make([]byte, size)
+	}
+	return c.buf
+}
+
+// Null reports whether the column is NULL. It is only meaningful once
+// Read has been called.
+func (r *LOBReader) Null() bool {
+	return r.null
+}
+
+func (r *LOBReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.fill()
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// fill gets the next chunk of the column into pending.
+func (r *LOBReader) fill() {
+	h := api.SQLHSTMT(r.stmt.handle)
+	var ind api.SQLLEN
+	ret := api.SQLGetData(h, api.SQLUSMALLINT(r.col), r.cType, api.SQLPOINTER(unsafe.Pointer(&r.buf[0])), api.SQLLEN(len(r.buf)), &ind)
+	if ret == api.SQL_NO_DATA {
+		r.err = io.EOF
+		return
+	}
+	if IsError(ret) {
+		r.err = NewError("SQLGetData", h)
+		return
+	}
+	if ind == api.SQL_NULL_DATA {
+		r.null = true
+		r.err = io.EOF
+		return
+	}
+
+	// a truncated chunk fills the buffer except for the null terminator.
+	n := len(r.buf)
+	switch r.cType {
+	case api.SQL_C_CHAR:
+		n--
+	case api.SQL_C_WCHAR:
+		n = (n - 2) &^ 1
+	}
+	if ind != api.SQL_NO_TOTAL && int(ind) < n {
+		n = int(ind)
+	}
+	last := ret == api.SQL_SUCCESS
+	if last {
+		r.err = io.EOF
+	}
+	if r.cType == api.SQL_C_WCHAR {
+		r.pending = r.decode(r.buf[:n], last)
+	} else {
+		r.pending = r.buf[:n]
+	}
+}
+
+// decode converts a chunk of UTF-16 to UTF-8, holding back a high
+// surrogate at the end of the chunk until its pair arrives.
+func (r *LOBReader) decode(b []byte, last bool) []byte {
+	u := make([]uint16, 0, len(b)/2+1)
+	if r.high != 0 {
+		u = append(u, r.high)
+		r.high = 0
+	}
+	for i := 0; i+1 < len(b); i += 2 {
+		u = append(u, *(*uint16)(unsafe.Pointer(&b[i])))
+	}
+	if n := len(u); !last && n > 0 && utf16.IsSurrogate(rune(u[n-1])) && u[n-1] < 0xdc00 {
+		r.high = u[n-1]
+		u = u[:n-1]
+	}
+	out := make([]byte, 0, len(u)*3)
+	var rb [utf8.UTFMax]byte
+	for _, c := range utf16.Decode(u) {
+		out = append(out, rb[:utf8.EncodeRune(rb[:], c)]...)
+	}
+	return out
+}
+
+// readAll reads a whole column with a LOBReader. It returns nil if
+// the column is NULL.
+func (stmt *Statement) readAll(field_index int, cType api.SQLSMALLINT, size int) ([]byte, error) {
+	r := stmt.newLOBReader(field_index, cType, stmt.getDataBuffer(field_index, size))
+	var b bytes.Buffer
+	if _, err := b.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	if r.null {
+		return nil, nil
+	}
+	return b.Bytes(), nil
+}
@@ -23,6 +23,9 @@ var (
 type Connection struct {
 	Dbc       api.SQLHANDLE
 	connected bool
+	warnings  warnings
+	// completed is the connection string SQLDriverConnect connected with.
+	completed string
 }
 
 type Statement struct {
@@ -31,6 +34,19 @@ type Statement struct {
 	scrollable bool
 
 	handle api.SQLHANDLE
+
+	// params holds the buffers bound to each parameter marker.
+	params    []*paramBuffer
+	outParams []*outParam
+	streams   []*streamParam
+
+	// result set state, see fetch.go.
+	fetchSize int
+	cols      []column
+	rowArray  *rowArray
+	rowByRow  bool
+
+	warnings warnings
 }
 
 func initEnv() (err error) {
@@ -50,16 +66,30 @@ func initEnv() (err error) {
 	return nil
 }
 
+// Connect opens a connection with SQLDriverConnect. params are
+// Options, such as LoginTimeout, that configure the connection, and
+// optionally a WarningFunc.
 func Connect(dsn string, params ...interface{}) (conn *Connection, err error) {
+	opts, warn, err := connectOptions(params)
+	if err != nil {
+		return nil, err
+	}
+
 	var h api.SQLHANDLE
 	ret := api.SQLAllocHandle(api.SQL_HANDLE_DBC, Genv, &h)
 	if IsError(ret) {
 		err := NewError("SQLAllocHandle", h)
 		return nil, err
 	}
+	conn = &Connection{Dbc: h}
+	conn.warnings.fn = warn
+	if err := conn.setOptions(opts, true); err != nil {
+		api.SQLFreeHandle(api.SQL_HANDLE_DBC, h)
+		return nil, err
+	}
 
 	var stringLength2 api.SQLSMALLINT
-	outBuf := make([]byte, BUFFER_SIZE*2)
+	outBuf := make([]uint16, BUFFER_SIZE)
 	outConnectionString := (*api.SQLWCHAR)(unsafe.Pointer(&outBuf[0]))
 
 	ret = api.SQLDriverConnect(api.SQLHDBC(h),
@@ -73,9 +103,20 @@ func Connect(dsn string, params ...interface{}) (conn *Connection, err error) {
 
 	if IsError(ret) {
 		err := NewError("SQLDriverConnect", api.SQLHDBC(h))
+		api.SQLFreeHandle(api.SQL_HANDLE_DBC, h)
 		return nil, err
 	}
-	return &Connection{Dbc: h, connected: true}, nil
+	conn.connected = true
+	conn.completed = UTF16ToString(outBuf)
+	if err := conn.warnings.check(ret, "SQLDriverConnect", api.SQLHDBC(h)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.setOptions(opts, false); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
 }
 
 func (conn *Connection) ExecDirect(sql string) (stmt *Statement, err error) {
@@ -90,12 +131,17 @@ func (conn *Connection) ExecDirect(sql string) (stmt *Statement, err error) {
 		stmt.Close()
 		return nil, err
 	}
+	if err := stmt.warnings.check(ret, "SQLExecDirect", api.SQLHSTMT(stmt.handle)); err != nil {
+		stmt.Close()
+		return nil, err
+	}
 	stmt.executed = true
 	return stmt, nil
 }
 
 func (conn *Connection) newStmt() (*Statement, error) {
 	stmt := &Statement{}
+	stmt.warnings.fn = conn.warnings.fn
 
 	ret := api.SQLAllocHandle(api.SQL_HANDLE_STMT, conn.Dbc, &stmt.handle)
 	if IsError(ret) {
@@ -105,12 +151,25 @@ func (conn *Connection) newStmt() (*Statement, error) {
 	return stmt, nil
 }
 
+// Prepare prepares sql on a new statement. params are
+// StatementOptions, such as ScrollableCursor, applied beforehand.
 func (conn *Connection) Prepare(sql string, params ...interface{}) (*Statement, error) {
 	wsql := StringToUTF16Ptr(sql)
 	stmt, err := conn.newStmt()
 	if err != nil {
 		return nil, err
 	}
+	for _, p := range params {
+		opt, ok := p.(StatementOption)
+		if !ok {
+			stmt.Close()
+			return nil, fmt.Errorf("odbc: unsupported Prepare parameter of type %T", p)
+		}
+		if err := opt(stmt); err != nil {
+			stmt.Close()
+			return nil, err
+		}
+	}
 	ret := api.SQLPrepare(api.SQLHSTMT(stmt.handle), (*api.SQLWCHAR)(unsafe.Pointer(wsql)), api.SQLINTEGER(len(sql)))
 	if IsError(ret) {
 		err := NewError("SQLPrepare", api.SQLHSTMT(stmt.handle))
@@ -125,8 +184,9 @@ func (conn *Connection) Commit() (err error) {
 	ret := api.SQLEndTran(api.SQL_HANDLE_DBC, conn.Dbc, api.SQL_COMMIT)
 	if IsError(ret) {
 		err = NewError("SQLEndTran", conn.Dbc)
+		return
 	}
-	return
+	return conn.warnings.check(ret, "SQLEndTran", api.SQLHDBC(conn.Dbc))
 }
 
 func (conn *Connection) AutoCommit(b bool) (err error) {
@@ -143,6 +203,12 @@ func (conn *Connection) AutoCommit(b bool) (err error) {
 	return
 }
 
+// AutoCommitEnabled reports whether the connection is in autocommit mode.
+func (conn *Connection) AutoCommitEnabled() (bool, error) {
+	v, err := conn.getConnectAttrUint(api.SQL_ATTR_AUTOCOMMIT)
+	return v == api.SQL_AUTOCOMMIT_ON, err
+}
+
 func (conn *Connection) BeginTransaction() (err error) {
 	ret := api.SQLSetConnectAttr(api.SQLHDBC(conn.Dbc), api.SQL_ATTR_AUTOCOMMIT, api.SQLPOINTER(unsafe.Pointer(uintptr(api.SQL_AUTOCOMMIT_OFF))), api.SQL_IS_UINTEGER)
 	if IsError(ret) {
@@ -155,56 +221,94 @@ func (conn *Connection) Rollback() (err error) {
 	ret := api.SQLEndTran(api.SQL_HANDLE_DBC, conn.Dbc, api.SQL_ROLLBACK)
 	if IsError(ret) {
 		err = NewError("SQLEndTran", conn.Dbc)
+		return
 	}
-	return
+	return conn.warnings.check(ret, "SQLEndTran", api.SQLHDBC(conn.Dbc))
 }
 
-func (conn *Connection) ServerInfo() (string, string, string, error) {
-	var info_len api.SQLSMALLINT
-	p := make([]byte, INFO_BUFFER_LEN)
-	ret := api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQL_DATABASE_NAME, api.SQLPOINTER(unsafe.Pointer(&p[0])), INFO_BUFFER_LEN, &info_len)
-	if IsError(ret) {
-		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
-		return "", "", "", err
+// IsolationLevel returns the SQL_TXN_* isolation level of the connection.
+func (conn *Connection) IsolationLevel() (int, error) {
+	v, err := conn.getConnectAttrUint(api.SQL_ATTR_TXN_ISOLATION)
+	return int(v), err
+}
+
+// SetIsolationLevel sets the SQL_TXN_* isolation level used by the next transaction.
+// It must not be called while a transaction is open.
+func (conn *Connection) SetIsolationLevel(level int) error {
+	return conn.setConnectAttrUint(api.SQL_ATTR_TXN_ISOLATION, uintptr(level))
+}
+
+// SupportedIsolationLevels returns the bitmask of SQL_TXN_* isolation levels
+// the driver accepts, as reported by SQL_TXN_ISOLATION_OPTION.
+func (conn *Connection) SupportedIsolationLevels() (int, error) {
+	levels, err := conn.InfoUint32(api.SQL_TXN_ISOLATION_OPTION)
+	return int(levels), err
+}
+
+// ReadOnly reports whether the connection access mode is SQL_MODE_READ_ONLY.
+func (conn *Connection) ReadOnly() (bool, error) {
+	v, err := conn.getConnectAttrUint(api.SQL_ATTR_ACCESS_MODE)
+	return v == api.SQL_MODE_READ_ONLY, err
+}
+
+// SetReadOnly switches the connection access mode between
+// SQL_MODE_READ_ONLY and SQL_MODE_READ_WRITE.
+func (conn *Connection) SetReadOnly(b bool) error {
+	mode := uintptr(api.SQL_MODE_READ_WRITE)
+	if b {
+		mode = uintptr(api.SQL_MODE_READ_ONLY)
 	}
-	db := string(p[0:info_len])
-	ret = api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQL_DBMS_VER, api.SQLPOINTER(unsafe.Pointer(&p[0])), INFO_BUFFER_LEN, &info_len)
+	return conn.setConnectAttrUint(api.SQL_ATTR_ACCESS_MODE, mode)
+}
+
+// Dead reports whether the driver has found the connection to the
+// server lost (SQL_ATTR_CONNECTION_DEAD). It does not contact the
+// server; drivers without the attribute return an error.
+func (conn *Connection) Dead() (bool, error) {
+	v, err := conn.getConnectAttrUint(api.SQL_ATTR_CONNECTION_DEAD)
+	return v == api.SQL_CD_TRUE, err
+}
+
+func (conn *Connection) getConnectAttrUint(attr api.SQLINTEGER) (api.SQLUINTEGER, error) {
+	var v api.SQLUINTEGER
+	ret := api.SQLGetConnectAttr(api.SQLHDBC(conn.Dbc), attr, api.SQLPOINTER(unsafe.Pointer(&v)), api.SQL_IS_UINTEGER, nil)
 	if IsError(ret) {
-		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
-		return db, "", "", err
+		err := NewError("SQLGetConnectAttr", api.SQLHDBC(conn.Dbc))
+		return 0, err
 	}
-	ver := string(p[0:info_len])
-	ret = api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQL_SERVER_NAME, api.SQLPOINTER(unsafe.Pointer(&p[0])), INFO_BUFFER_LEN, &info_len)
+	return v, nil
+}
+
+func (conn *Connection) setConnectAttrUint(attr api.SQLINTEGER, v uintptr) error {
+	ret := api.SQLSetConnectAttr(api.SQLHDBC(conn.Dbc), attr, api.SQLPOINTER(unsafe.Pointer(v)), api.SQL_IS_UINTEGER)
 	if IsError(ret) {
-		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
-		return db, ver, "", err
+		err := NewError("SQLSetConnectAttr", api.SQLHDBC(conn.Dbc))
+		return err
 	}
-	server := string(p[0:info_len])
-	return db, ver, server, nil
+	return conn.warnings.check(ret, "SQLSetConnectAttr", api.SQLHDBC(conn.Dbc))
+}
+
+// ServerInfo returns the database name, the DBMS version and the server name.
+func (conn *Connection) ServerInfo() (string, string, string, error) {
+	return conn.infoStrings(api.SQL_DATABASE_NAME, api.SQL_DBMS_VER, api.SQL_SERVER_NAME)
 }
 
+// ClientInfo returns the driver name, the ODBC version the driver
+// supports and the driver version.
 func (conn *Connection) ClientInfo() (string, string, string, error) {
-	var info_len api.SQLSMALLINT
-	p := make([]byte, INFO_BUFFER_LEN)
-	ret := api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQL_DRIVER_NAME, api.SQLPOINTER(unsafe.Pointer(&p[0])), INFO_BUFFER_LEN, &info_len)
-	if IsError(ret) {
-		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
-		return "", "", "", err
-	}
-	drv_name := string(p[0:info_len])
-	ret = api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQL_DRIVER_ODBC_VER, api.SQLPOINTER(unsafe.Pointer(&p[0])), INFO_BUFFER_LEN, &info_len)
-	if IsError(ret) {
-		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
-		return "", "", "", err
-	}
-	drv_odbc_ver := string(p[0:info_len])
-	ret = api.SQLGetInfo(api.SQLHDBC(conn.Dbc), api.SQL_DRIVER_VER, api.SQLPOINTER(unsafe.Pointer(&p[0])), INFO_BUFFER_LEN, &info_len)
-	if IsError(ret) {
-		err := NewError("SQLGetInfo", api.SQLHDBC(conn.Dbc))
-		return "", "", "", err
+	return conn.infoStrings(api.SQL_DRIVER_NAME, api.SQL_DRIVER_ODBC_VER, api.SQL_DRIVER_VER)
+}
+
+func (conn *Connection) infoStrings(a, b, c int) (string, string, string, error) {
+	var v [3]string
+	for i, infoType := range []int{a, b, c} {
+		s, err := conn.InfoString(infoType)
+		if err != nil {
+			return v[0], v[1], v[2], err
+		}
+		v[i] = s
 	}
-	drv_ver := string(p[0:info_len])
-	return drv_name, drv_odbc_ver, drv_ver, nil
+	return v[0], v[1], v[2], nil
 }
 
 func (conn *Connection) Close() error {
@@ -253,32 +357,21 @@ func (stmt *Statement) NumParams() int {
 }
 
 func (stmt *Statement) Execute(params ...interface{}) error {
+	return stmt.execute(params)
+}
+
+func (stmt *Statement) Execute2(params []driver.Value) error {
+	var args []interface{}
 	if params != nil {
-		var cParams api.SQLSMALLINT
-		ret := api.SQLNumParams(api.SQLHSTMT(stmt.handle), &cParams)
-		if IsError(ret) {
-			err := NewError("SQLNumParams", api.SQLHSTMT(stmt.handle))
-			return err
-		}
-		for i := 0; i < int(cParams); i++ {
-			stmt.BindParam(i+1, params[i])
+		args = make([]interface{}, len(params))
+		for i, p := range params {
+			args[i] = p
 		}
 	}
-	ret := api.SQLExecute(api.SQLHSTMT(stmt.handle))
-	if ret == api.SQL_NEED_DATA {
-		// TODO
-		//		send_data(stmt)
-	} else if ret == api.SQL_NO_DATA {
-		// Execute NO DATA
-	} else if IsError(ret) {
-		err := NewError("SQLExecute", api.SQLHSTMT(stmt.handle))
-		return err
-	}
-	stmt.executed = true
-	return nil
+	return stmt.execute(args)
 }
 
-func (stmt *Statement) Execute2(params []driver.Value) error {
+func (stmt *Statement) execute(params []interface{}) error {
 	if params != nil {
 		var cParams api.SQLSMALLINT
 		ret := api.SQLNumParams(api.SQLHSTMT(stmt.handle), &cParams)
@@ -286,23 +379,40 @@ func (stmt *Statement) Execute2(params []driver.Value) error {
 			err := NewError("SQLNumParams", api.SQLHSTMT(stmt.handle))
 			return err
 		}
+		if len(params) != int(cParams) {
+			return fmt.Errorf("odbc: statement expects %d parameters, got %d", cParams, len(params))
+		}
 		for i := 0; i < int(cParams); i++ {
-			stmt.BindParam(i+1, params[i])
+			if err := stmt.BindParam(i+1, params[i]); err != nil {
+				return err
+			}
 		}
 	}
+	if err := stmt.checkStreams(); err != nil {
+		return err
+	}
+	stmt.resetFetch()
+	stmt.warnings.list = nil
+	apiName := "SQLExecute"
 	ret := api.SQLExecute(api.SQLHSTMT(stmt.handle))
 	if ret == api.SQL_NEED_DATA {
-		// TODO
-		//		send_data(stmt)
-	} else if ret == api.SQL_NO_DATA {
+		var err error
+		if ret, err = stmt.putData(); err != nil {
+			return err
+		}
+		apiName = "SQLParamData"
+	}
+	if ret == api.SQL_NO_DATA {
 		// Execute NO DATA
 		// success but no data to report
 	} else if IsError(ret) {
-		err := NewError("SQLExecute", api.SQLHSTMT(stmt.handle))
+		err := NewError(apiName, api.SQLHSTMT(stmt.handle))
+		return err
+	} else if err := stmt.warnings.check(ret, apiName, api.SQLHSTMT(stmt.handle)); err != nil {
 		return err
 	}
 	stmt.executed = true
-	return nil
+	return stmt.storeOutParams()
 }
 
 func (stmt *Statement) Fetch() (bool, error) {
@@ -314,6 +424,9 @@ func (stmt *Statement) Fetch() (bool, error) {
 		err := NewError("SQLFetch", api.SQLHSTMT(stmt.handle))
 		return false, err
 	}
+	if err := stmt.warnings.check(ret, "SQLFetch", api.SQLHSTMT(stmt.handle)); err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
@@ -376,62 +489,63 @@ func (r *Row) Length() int {
 func (stmt *Statement) FetchAll() (rows []*Row, err error) {
 	for {
 		row, err := stmt.FetchOne()
-		if err != nil || row == nil {
+		if err != nil {
+			return rows, err
+		}
+		if row == nil {
 			break
 		}
 		rows = append(rows, row)
 	}
 
-	return rows, err
+	return rows, nil
 }
 
 func (stmt *Statement) FetchOne() (*Row, error) {
-	ok, err := stmt.Fetch()
+	ok, err := stmt.fetchRow()
 	if !ok {
 		return nil, err
 	}
-	n, _ := stmt.NumFields()
+	return stmt.row()
+}
+
+// row returns the fields of the row the cursor is on.
+func (stmt *Statement) row() (*Row, error) {
+	var err error
 	row := new(Row)
-	row.Data = make([]interface{}, n)
-	for i := 0; i < n; i++ {
-		v, _, _, _ := stmt.GetField(i)
-		row.Data[i] = v
+	row.Data = make([]interface{}, len(stmt.cols))
+	for i := range row.Data {
+		if row.Data[i], err = stmt.field(i); err != nil {
+			return nil, err
+		}
 	}
 	return row, nil
 }
 
 func (stmt *Statement) FetchOne2(row []driver.Value) (eof bool, err error) {
-	ok, err := stmt.Fetch()
+	ok, err := stmt.fetchRow()
 	if !ok && err == nil {
 		return !ok, nil
 	} else if err != nil {
 		return false, err
 	}
-	n, _ := stmt.NumFields()
-
-	//if n != len(row) {return false, errors.New(fmt.Sprintf("argument length must be equal to %d", n))}
-
-	for i := 0; i < n; i++ {
-		v, _, _, _ := stmt.GetField(i)
-		row[i] = v
+	for i := range stmt.cols {
+		if row[i], err = stmt.field(i); err != nil {
+			return false, err
+		}
 	}
 	return false, nil
 }
 
 func (stmt *Statement) GetField(field_index int) (v interface{}, ftype int, flen int, err error) {
-	var field_type C.int
-	var field_len api.SQLLEN
-	var ll api.SQLSMALLINT
-
-	ret := api.SQLColAttributeUIntPtr(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_DESC_CONCISE_TYPE, api.SQLPOINTER(unsafe.Pointer(uintptr(0))), api.SQLSMALLINT(0), &ll, unsafe.Pointer(&field_type))
-	if IsError(ret) {
-		return nil, 0, 0, err
-	}
-	ret = api.SQLColAttributeUIntPtr(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_DESC_LENGTH, api.SQLPOINTER(unsafe.Pointer(uintptr(0))), api.SQLSMALLINT(0), &ll, unsafe.Pointer(&field_len))
-	if IsError(ret) {
+	cols, err := stmt.columns()
+	if err != nil {
 		return nil, 0, 0, err
 	}
+	field_type := cols[field_index].ftype
+	field_len := api.SQLLEN(cols[field_index].size)
 
+	var ret api.SQLRETURN
 	var fl api.SQLLEN = api.SQLLEN(field_len)
 	switch int(field_type) {
 	case api.SQL_BIT:
@@ -443,7 +557,7 @@ func (stmt *Statement) GetField(field_index int) (v interface{}, ftype int, flen
 			v = byte(value)
 		}
 	case api.SQL_INTEGER, api.SQL_SMALLINT, api.SQL_TINYINT:
-		var value C.long
+		var value api.SQLINTEGER
 		ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_LONG, api.SQLPOINTER(unsafe.Pointer(&value)), 0, &fl)
 		if fl == -1 {
 			v = nil
@@ -474,33 +588,26 @@ func (stmt *Statement) GetField(field_index int) (v interface{}, ftype int, flen
 		} else {
 			v = float64(value)
 		}
-	case api.SQL_WCHAR, api.SQL_WVARCHAR, api.SQL_WLONGVARCHAR:
-		value := make([]uint16, (field_len+1)*2)
-		ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_WCHAR, api.SQLPOINTER(unsafe.Pointer(&value[0])), (field_len+1)*2, &fl)
-		s := UTF16ToString(value)
-		v = s
-
-	case api.SQL_CHAR, api.SQL_VARCHAR, api.SQL_LONGVARCHAR:
-		var result string
-		var size int
-		for {
-			chunkSize := 1024
-			value := make([]uint8, chunkSize)
-			ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_CHAR, api.SQLPOINTER(unsafe.Pointer(&value[0])), api.SQLLEN(chunkSize), &fl)
-			if IsError(ret) || api.SQLLEN(ret) == api.SQL_NULL_DATA {
-				v = nil
-				break
-			} else if ret == api.SQL_NO_DATA {
-				break
-			} else if ret == api.SQL_SUCCESS {
-				result += string(value)
-				size += int(fl)
-				break
-			}
-			result += string(value)
-			size += int(fl)
+	case api.SQL_WCHAR, api.SQL_WVARCHAR, api.SQL_WLONGVARCHAR,
+		api.SQL_CHAR, api.SQL_VARCHAR, api.SQL_LONGVARCHAR:
+		// CHAR columns may hold up to 4 bytes per character once
+		// converted by the driver, plus the null terminator.
+		size := 0
+		if field_len > 0 {
+			size = int(field_len+1) * 4
+		}
+		var value []byte
+		value, err = stmt.readAll(field_index, lobCType(int(field_type)), size)
+		if err != nil {
+			return nil, int(field_type), 0, err
+		}
+		if value == nil {
+			fl = api.SQL_NULL_DATA
+			v = nil
+		} else {
+			fl = api.SQLLEN(len(value))
+			v = string(value)
 		}
-		v = result[:size]
 	case api.SQL_TYPE_TIMESTAMP, api.SQL_TYPE_DATE, api.SQL_TYPE_TIME, api.SQL_DATETIME:
 		var value api.SQL_TIMESTAMP_STRUCT
 		ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_TYPE_TIMESTAMP, api.SQLPOINTER(unsafe.Pointer(&value)), api.SQLLEN(unsafe.Sizeof(value)), &fl)
@@ -509,23 +616,27 @@ func (stmt *Statement) GetField(field_index int) (v interface{}, ftype int, flen
 		} else {
 			v = time.Date(int(value.Year), time.Month(value.Month), int(value.Day), int(value.Hour), int(value.Minute), int(value.Second), int(value.Fraction), time.UTC)
 		}
-	case api.SQL_BINARY, api.SQL_VARBINARY, api.SQL_LONGVARBINARY:
-		var vv int
-		ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_BINARY, api.SQLPOINTER(unsafe.Pointer(&vv)), 0, &fl)
-		if fl == -1 {
+	default:
+		// SQL_BINARY, SQL_VARBINARY, SQL_LONGVARBINARY and any other
+		// type are returned as bytes.
+		var value []byte
+		value, err = stmt.readAll(field_index, api.SQL_C_BINARY, int(field_len))
+		if err != nil {
+			return nil, int(field_type), 0, err
+		}
+		if value == nil {
+			fl = api.SQL_NULL_DATA
 			v = nil
 		} else {
-			value := make([]byte, fl)
-			ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_BINARY, api.SQLPOINTER(unsafe.Pointer(&value[0])), api.SQLLEN(fl), &fl)
+			fl = api.SQLLEN(len(value))
 			v = value
 		}
-	default:
-		value := make([]byte, field_len)
-		ret = api.SQLGetData(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(field_index+1), api.SQL_C_BINARY, api.SQLPOINTER(unsafe.Pointer(&value[0])), field_len, &fl)
-		v = value
 	}
 	if IsError(ret) {
 		err = NewError("SQLGetData", api.SQLHSTMT(stmt.handle))
+	} else if err = stmt.warnings.check(ret, "SQLGetData", api.SQLHSTMT(stmt.handle)); err != nil {
+		// such as 01S07, the fractional part of a NUMERIC was truncated.
+		return nil, int(field_type), 0, err
 	}
 	return v, int(field_type), int(fl), err
 }
@@ -552,94 +663,71 @@ func (stmt *Statement) GetParamType(index int) (int, int, int, int, error) {
 }
 
 func (stmt *Statement) BindParam(index int, param interface{}) error {
-	var ValueType api.SQLSMALLINT
-	var ParameterType api.SQLSMALLINT
-	var ColumnSize api.SQLULEN
-	var DecimalDigits api.SQLSMALLINT
-	var ParameterValuePtr api.SQLPOINTER
-	var BufferLength api.SQLLEN
-	var StrLen_or_IndPt api.SQLLEN
-	v := reflect.ValueOf(param)
+	switch out := param.(type) {
+	case Out:
+		return stmt.bindOutParam(index, out)
+	case *Out:
+		return stmt.bindOutParam(index, *out)
+	case Stream:
+		return stmt.bindStream(index, out)
+	case *Stream:
+		return stmt.bindStream(index, *out)
+	}
+	p, err := newInParam(param)
+	if err != nil {
+		return fmt.Errorf("odbc: parameter %d: %v", index, err)
+	}
 	if param == nil {
 		ft, _, _, _, err := stmt.GetParamType(index)
 		if err != nil {
 			return err
 		}
-		ParameterType = api.SQLSMALLINT(ft)
-		if ParameterType == api.SQL_UNKNOWN_TYPE {
-			ParameterType = api.SQL_VARCHAR
-		}
-		ValueType = api.SQL_C_DEFAULT
-		StrLen_or_IndPt = api.SQL_NULL_DATA
-		ColumnSize = 1
-	} else {
-		switch v.Kind() {
-		case reflect.Bool:
-			ParameterType = api.SQL_BIT
-			ValueType = api.SQL_C_BIT
-			var b [1]byte
-			if v.Bool() {
-				b[0] = 1
-			} else {
-				b[0] = 0
-			}
-			ParameterValuePtr = api.SQLPOINTER(unsafe.Pointer(&b[0]))
-			BufferLength = 1
-			StrLen_or_IndPt = 0
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			switch v.Type().Kind() {
-			case reflect.Int:
-			case reflect.Int8, reflect.Int16, reflect.Int32:
-				ParameterType = api.SQL_INTEGER
-				ValueType = api.SQL_C_LONG
-				var l C.long = C.long(v.Int())
-				ParameterValuePtr = api.SQLPOINTER(unsafe.Pointer(&l))
-				BufferLength = 4
-				StrLen_or_IndPt = 0
-			case reflect.Int64:
-				ParameterType = api.SQL_BIGINT
-				ValueType = api.SQL_C_SBIGINT
-				var ll C.longlong = C.longlong(v.Int())
-				ParameterValuePtr = api.SQLPOINTER(unsafe.Pointer(&ll))
-				BufferLength = 8
-				StrLen_or_IndPt = 0
-			}
-		case reflect.Float32, reflect.Float64:
-			ParameterType = api.SQL_DOUBLE
-			ValueType = api.SQL_C_DOUBLE
-			var d C.double = C.double(v.Float())
-			ParameterValuePtr = api.SQLPOINTER(unsafe.Pointer(&d))
-			BufferLength = 8
-			StrLen_or_IndPt = 0
-		case reflect.Complex64, reflect.Complex128:
-		case reflect.String:
-			var slen api.SQLUINTEGER = api.SQLUINTEGER(len(v.String()))
-			ParameterType = api.SQL_VARCHAR
-			ValueType = api.SQL_C_CHAR
-			s := []byte(v.String())
-			ParameterValuePtr = api.SQLPOINTER(unsafe.Pointer(&s[0]))
-			ColumnSize = api.SQLULEN(slen)
-			BufferLength = api.SQLLEN(slen + 1)
-			StrLen_or_IndPt = api.SQLLEN(slen)
-		default:
-			fmt.Println("Not support type", v)
+		if ft != api.SQL_UNKNOWN_TYPE {
+			p.sqlType = api.SQLSMALLINT(ft)
 		}
 	}
-	ret := api.SQLBindParameter(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(index), api.SQL_PARAM_INPUT, ValueType, ParameterType, ColumnSize, DecimalDigits, ParameterValuePtr, BufferLength, &StrLen_or_IndPt)
+	// the driver reads the value and length at SQLExecute time,
+	// so both are copied to memory owned by the statement.
+	b, err := newParamBuffer(len(p.buf))
+	if err != nil {
+		return err
+	}
+	copy(b.buf, p.buf)
+	*b.ind = p.ind
+	ret := api.SQLBindParameter(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(index), api.SQL_PARAM_INPUT, p.cType, p.sqlType, p.size, p.decimal, b.ptr(), api.SQLLEN(len(b.buf)), b.ind)
 	if IsError(ret) {
 		err := NewError("SQLBindParameter", api.SQLHSTMT(stmt.handle))
+		b.free()
 		return err
 	}
-
+	stmt.setParamBuffer(index, b)
 	return nil
 }
 
 func (stmt *Statement) NextResult() bool {
+	ok, _ := stmt.MoreResults()
+	return ok
+}
+
+// MoreResults moves to the next result of a batch or procedure call.
+// It returns false when there are no more results. The new result may be
+// a row count rather than a cursor; NumFields returns 0 in that case.
+func (stmt *Statement) MoreResults() (bool, error) {
+	stmt.resetFetch()
 	ret := api.SQLMoreResults(api.SQLHSTMT(stmt.handle))
 	if ret == api.SQL_NO_DATA {
-		return false
+		// some drivers only return output parameters
+		// after all results have been processed.
+		return false, stmt.storeOutParams()
 	}
-	return true
+	if IsError(ret) {
+		err := NewError("SQLMoreResults", api.SQLHSTMT(stmt.handle))
+		return false, err
+	}
+	if err := stmt.warnings.check(ret, "SQLMoreResults", api.SQLHSTMT(stmt.handle)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (stmt *Statement) NumRows() (int, error) {
@@ -672,7 +760,7 @@ func (stmt *Statement) FieldMetadata(col int) (*Field, error) {
 	var ColumnSize api.SQLULEN
 	var DecimalDigits api.SQLSMALLINT
 	var Nullable api.SQLSMALLINT
-	ColumnName := make([]byte, INFO_BUFFER_LEN)
+	ColumnName := make([]uint16, INFO_BUFFER_LEN)
 	ret := api.SQLDescribeCol(api.SQLHSTMT(stmt.handle),
 		api.SQLUSMALLINT(col),
 		(*api.SQLWCHAR)(unsafe.Pointer(&ColumnName[0])),
@@ -686,12 +774,74 @@ func (stmt *Statement) FieldMetadata(col int) (*Field, error) {
 		err := NewError("SQLDescribeCol", api.SQLHSTMT(stmt.handle))
 		return nil, err
 	}
-	field := &Field{string(ColumnName[0:NameLength]), int(DataType), int(ColumnSize), int(DecimalDigits), int(Nullable)}
+	if int(NameLength) > len(ColumnName) {
+		NameLength = api.SQLSMALLINT(len(ColumnName))
+	}
+	field := &Field{UTF16ToString(ColumnName[0:NameLength]), int(DataType), int(ColumnSize), int(DecimalDigits), int(Nullable)}
 	return field, nil
 }
 
+// ColAttributeString returns a character column attribute (SQL_DESC_*)
+// of the 1-based column col.
+func (stmt *Statement) ColAttributeString(col int, field int) (string, error) {
+	var l api.SQLSMALLINT
+	buf := make([]byte, INFO_BUFFER_LEN)
+	ret := api.SQLColAttribute(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(col), api.SQLUSMALLINT(field), api.SQLPOINTER(unsafe.Pointer(&buf[0])), api.SQLSMALLINT(len(buf)), &l, nil)
+	if IsError(ret) {
+		err := NewError("SQLColAttribute", api.SQLHSTMT(stmt.handle))
+		return "", err
+	}
+	if int(l) > len(buf) {
+		l = api.SQLSMALLINT(len(buf))
+	}
+	return string(buf[0:l]), nil
+}
+
+// ColAttributeInt returns a numeric column attribute (SQL_DESC_*)
+// of the 1-based column col.
+func (stmt *Statement) ColAttributeInt(col int, field int) (int, error) {
+	var v api.SQLLEN
+	ret := api.SQLColAttribute(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(col), api.SQLUSMALLINT(field), nil, 0, nil, &v)
+	if IsError(ret) {
+		err := NewError("SQLColAttribute", api.SQLHSTMT(stmt.handle))
+		return 0, err
+	}
+	return int(v), nil
+}
+
+// CloseCursor closes the open cursor, if any, and discards pending results.
+// The statement stays prepared and can be executed again.
+func (stmt *Statement) CloseCursor() error {
+	stmt.resetFetch()
+	ret := api.SQLFreeStmt(api.SQLHSTMT(stmt.handle), api.SQL_CLOSE)
+	if IsError(ret) {
+		err := NewError("SQLFreeStmt", api.SQLHSTMT(stmt.handle))
+		return err
+	}
+	stmt.executed = false
+	return nil
+}
+
+// ResetParams unbinds the parameters and releases their buffers,
+// along with the Out destinations and Stream readers bound to them.
+// The next Execute has to pass all the parameters again.
+func (stmt *Statement) ResetParams() error {
+	ret := api.SQLFreeStmt(api.SQLHSTMT(stmt.handle), api.SQL_RESET_PARAMS)
+	if IsError(ret) {
+		err := NewError("SQLFreeStmt", api.SQLHSTMT(stmt.handle))
+		return err
+	}
+	stmt.freeParams()
+	return nil
+}
+
 func (stmt *Statement) free() {
-	api.SQLFreeHandle(api.SQL_HANDLE_STMT, stmt.handle)
+	if stmt.handle != api.SQLHANDLE(api.SQL_NULL_HANDLE) {
+		api.SQLFreeHandle(api.SQL_HANDLE_STMT, stmt.handle)
+		stmt.handle = api.SQLHANDLE(api.SQL_NULL_HANDLE)
+	}
+	stmt.resetFetch()
+	stmt.freeParams()
 }
 
 func (stmt *Statement) Close() {
@@ -2,10 +2,19 @@ package odbc
 
 import (
 	//	"github.com/jooita/sql/odbc"
+	"database/sql/driver"
+	"errors"
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
 )
 
 var (
@@ -53,3 +62,289 @@ func TestOdbc(t *testing.T) {
 	stmt.Close()
 	conn.Close()
 }
+
+func TestNewInParam(t *testing.T) {
+	tests := []struct {
+		v       interface{}
+		cType   api.SQLSMALLINT
+		sqlType api.SQLSMALLINT
+		ind     api.SQLLEN
+	}{
+		{nil, api.SQL_C_DEFAULT, api.SQL_VARCHAR, api.SQL_NULL_DATA},
+		{true, api.SQL_C_BIT, api.SQL_BIT, 1},
+		{int(1), api.SQL_C_SBIGINT, api.SQL_BIGINT, 8},
+		{int16(1), api.SQL_C_LONG, api.SQL_INTEGER, 4},
+		{uint64(1), api.SQL_C_UBIGINT, api.SQL_BIGINT, 8},
+		{uint64(math.MaxUint64), api.SQL_C_UBIGINT, api.SQL_DECIMAL, 8},
+		{float32(1), api.SQL_C_FLOAT, api.SQL_REAL, 4},
+		{1.5, api.SQL_C_DOUBLE, api.SQL_DOUBLE, 8},
+		{"héllo", api.SQL_C_WCHAR, api.SQL_WVARCHAR, 10},
+		{"", api.SQL_C_WCHAR, api.SQL_WVARCHAR, 0},
+		{[]byte{1, 2, 3}, api.SQL_C_BINARY, api.SQL_VARBINARY, 3},
+		{time.Date(2018, 3, 3, 15, 4, 5, 123456000, time.UTC), api.SQL_C_TYPE_TIMESTAMP, api.SQL_TYPE_TIMESTAMP, 16},
+		{Date{2018, 3, 3}, api.SQL_C_TYPE_DATE, api.SQL_TYPE_DATE, 6},
+		{Time{15, 4, 5}, api.SQL_C_TYPE_TIME, api.SQL_TYPE_TIME, 6},
+	}
+	for _, tt := range tests {
+		p, err := newInParam(tt.v)
+		if err != nil {
+			t.Errorf("%T: %v", tt.v, err)
+			continue
+		}
+		if p.cType != tt.cType || p.sqlType != tt.sqlType || p.ind != tt.ind {
+			t.Errorf("%T: got (%d, %d, %d), want (%d, %d, %d)", tt.v, p.cType, p.sqlType, p.ind, tt.cType, tt.sqlType, tt.ind)
+		}
+	}
+
+	p, _ := newInParam(time.Date(2018, 3, 3, 15, 4, 5, 123456000, time.UTC))
+	if p.decimal != 6 || p.size != 26 {
+		t.Errorf("timestamp precision: got (%d, %d), want (26, 6)", p.size, p.decimal)
+	}
+	if _, err := newInParam(struct{}{}); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestParamBufferRelease(t *testing.T) {
+	stmt := &Statement{}
+	a, err := newParamBuffer(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt.setParamBuffer(2, a)
+	stmt.outParams = append(stmt.outParams, &outParam{paramBuffer: a, index: 2})
+
+	// binding the parameter again releases the previous buffer.
+	b, err := newParamBuffer(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt.setParamBuffer(2, b)
+	if a.mem != nil {
+		t.Error("re-binding: previous buffer not released")
+	}
+	if len(stmt.params) != 2 || stmt.params[1] != b || len(stmt.outParams) != 0 {
+		t.Errorf("re-binding: got params %v, outParams %v", stmt.params, stmt.outParams)
+	}
+
+	stmt.Close()
+	if b.mem != nil || stmt.params != nil {
+		t.Error("Close: buffer not released")
+	}
+}
+
+func TestLOBReaderDecode(t *testing.T) {
+	want := "a😀b한"
+	u := utf16.Encode([]rune(want))
+	b := make([]byte, len(u)*2)
+	for i, c := range u {
+		*(*uint16)(unsafe.Pointer(&b[i*2])) = c
+	}
+
+	// split the chunks inside the surrogate pair of the emoji.
+	r := &LOBReader{}
+	got := string(r.decode(b[:4], false)) + string(r.decode(b[4:], true))
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGetDataBuffer(t *testing.T) {
+	stmt := &Statement{cols: []column{{ftype: api.SQL_BINARY, size: 4}}}
+	b := stmt.getDataBuffer(0, 4)
+	if len(b) != 4 {
+		t.Errorf("got %d bytes, want 4", len(b))
+	}
+	if c := stmt.getDataBuffer(0, 4); &c[0] != &b[0] {
+		t.Error("the buffer of the column was not reused")
+	}
+	for _, size := range []int{0, GET_DATA_CHUNK_SIZE + 1} {
+		if n := len(stmt.getDataBuffer(0, size)); n != GET_DATA_CHUNK_SIZE {
+			t.Errorf("size %d: got %d bytes, want %d", size, n, GET_DATA_CHUNK_SIZE)
+		}
+	}
+}
+
+func TestBoundColumnValue(t *testing.T) {
+	c := boundColumn{cType: api.SQL_C_WCHAR, width: 8, ind: make([]api.SQLLEN, 3), data: make([]byte, 24)}
+	for i, s := range []string{"ab", "", "abcd"} {
+		u := utf16.Encode([]rune(s))
+		for j, w := range u {
+			*(*uint16)(unsafe.Pointer(&c.data[i*c.width+j*2])) = w
+		}
+		c.ind[i] = api.SQLLEN(len(u) * 2)
+	}
+	c.ind[1] = api.SQL_NULL_DATA
+
+	if v, err := c.value(0, 0); err != nil || v != "ab" {
+		t.Errorf("row 0: got %v, %v", v, err)
+	}
+	if v, err := c.value(0, 1); err != nil || v != nil {
+		t.Errorf("row 1: got %v, %v", v, err)
+	}
+	// 4 characters and the terminator don't fit 8 bytes.
+	if _, err := c.value(0, 2); err == nil {
+		t.Error("row 2: expected a truncation error")
+	}
+}
+
+func TestNewBatchColumn(t *testing.T) {
+	c, err := newBatchColumn(1, []interface{}{"a", nil, "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// widest value is "abc" plus its UTF-16 terminator.
+	if c.cType != api.SQL_C_WCHAR || c.size != 3 || c.width != 8 {
+		t.Errorf("got cType %d, size %d, width %d", c.cType, c.size, c.width)
+	}
+	if c.params[1].ind != api.SQL_NULL_DATA {
+		t.Errorf("nil value: got indicator %d", c.params[1].ind)
+	}
+
+	if _, err := newBatchColumn(1, []interface{}{1, "a"}); err == nil {
+		t.Error("expected an error for mixed types")
+	}
+}
+
+func TestBatchChunk(t *testing.T) {
+	for _, c := range []struct{ rows, width, want int }{
+		{1000, 64, 1000},
+		{MAX_BATCH_SIZE, 2, MAX_BATCH_SIZE / 2},
+		{10, MAX_BATCH_SIZE, 1},
+	} {
+		if got := batchChunk(c.rows, c.width); got != c.want {
+			t.Errorf("batchChunk(%d, %d): got %d, want %d", c.rows, c.width, got, c.want)
+		}
+	}
+}
+
+func TestDriverAttributes(t *testing.T) {
+	list := utf16.Encode([]rune("Driver=/usr/lib/libmyodbc8w.so\x00Setup=\x00UsageCount=1\x00\x00"))
+	m := driverAttributes(list)
+	if len(m) != 3 || m["Driver"] != "/usr/lib/libmyodbc8w.so" || m["Setup"] != "" || m["UsageCount"] != "1" {
+		t.Errorf("got %v", m)
+	}
+}
+
+func TestEnumerateTruncated(t *testing.T) {
+	long := make([]uint16, 1<<15-1)
+	for i := range long {
+		long[i] = 'x'
+	}
+	list := [][2][]uint16{{utf16.Encode([]rune("a")), nil}, {utf16.Encode([]rune("b")), long}, {utf16.Encode([]rune("c")), nil}}
+	i := 0
+	var got [][2][]uint16
+	err := enumerate("SQLDrivers", api.SQL_FETCH_FIRST, func(dir api.SQLUSMALLINT, a, b []uint16, na, nb *api.SQLSMALLINT) api.SQLRETURN {
+		if dir == api.SQL_FETCH_FIRST {
+			i = 0
+		}
+		if i == len(list) {
+			return api.SQL_NO_DATA
+		}
+		e := list[i]
+		i++
+		a[copy(a[:len(a)-1], e[0])] = 0
+		b[copy(b[:len(b)-1], e[1])] = 0
+		*na, *nb = api.SQLSMALLINT(len(e[0])), api.SQLSMALLINT(len(e[1]))
+		return api.SQL_SUCCESS
+	}, func(a, b []uint16) {
+		got = append(got, [2][]uint16{a, b})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the entry after the one longer than the largest buffer is kept.
+	if len(got) != 3 || UTF16ToString(got[2][0]) != "c" {
+		t.Fatalf("got %d entries", len(got))
+	}
+	if n := len(got[1][1]); n != 1<<15-2 {
+		t.Errorf("truncated entry: got %d characters, want %d", n, 1<<15-2)
+	}
+}
+
+func TestInfoBufferLen(t *testing.T) {
+	for _, c := range []struct {
+		n    api.SQLSMALLINT
+		want int
+	}{
+		{100, 51},
+		{32766, MAX_INFO_BUFFER_LEN},
+		// SQL_NO_TOTAL
+		{-4, MAX_INFO_BUFFER_LEN},
+	} {
+		if got := infoBufferLen(c.n); got != c.want {
+			t.Errorf("infoBufferLen(%d): got %d, want %d", c.n, got, c.want)
+		}
+	}
+}
+
+func TestStrictWarnings(t *testing.T) {
+	truncated := []DiagRecord{{State: "01004", Message: "String data, right truncated"}}
+	if err := StrictWarnings("01004")("SQLFetch", truncated); err == nil {
+		t.Error("01004 should fail")
+	}
+	if err := StrictWarnings("01")("SQLFetch", truncated); err == nil {
+		t.Error("class 01 should fail")
+	}
+	if err := StrictWarnings("01S02")("SQLFetch", truncated); err != nil {
+		t.Errorf("01S02 should not fail: %v", err)
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := error(&Error{APIName: "SQLExecute", Diag: []DiagRecord{
+		{State: "01000", Message: "warning"},
+		{State: "23000", NativeError: 1062, Message: "Duplicate entry"},
+	}})
+	if !errors.Is(err, ErrConstraintViolation) {
+		t.Error("expected ErrConstraintViolation")
+	}
+	if errors.Is(err, ErrSyntax) || errors.Is(err, driver.ErrBadConn) {
+		t.Error("unexpected classification")
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.Diag[1].NativeError != 1062 {
+		t.Error("errors.As lost the diagnostic records")
+	}
+
+	lost := fmt.Errorf("query: %w", &Error{Diag: []DiagRecord{{State: "08S01"}}})
+	if !errors.Is(lost, driver.ErrBadConn) || !errors.Is(lost, ErrConnection) {
+		t.Error("08S01 should be ErrBadConn and ErrConnection")
+	}
+}
+
+func TestConnString(t *testing.T) {
+	c, err := ParseConnString(" DRIVER={MySQL ODBC 8.0 Driver}; Server=db;uid=me;PWD={p;w}}d};dsn=x;;DSN=y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := c.Get("driver"); v != "MySQL ODBC 8.0 Driver" {
+		t.Errorf("driver: got %q", v)
+	}
+	if v, _ := c.Get("pwd"); v != "p;w}d" {
+		t.Errorf("pwd: got %q", v)
+	}
+	if v, _ := c.Get("DSN"); v != "x" {
+		t.Errorf("the first DSN should win, got %q", v)
+	}
+	c.Set("SERVER", "db2")
+	c.Del("dsn")
+	want := "DRIVER={MySQL ODBC 8.0 Driver};Server=db2;uid=me;PWD={p;w}}d}"
+	if got := c.Encode(); got != want {
+		t.Errorf("Encode: got %q, want %q", got, want)
+	}
+	if got := c.String(); strings.Contains(got, "p;w") || !strings.Contains(got, "PWD=xxxxx") {
+		t.Errorf("String does not redact the password: %q", got)
+	}
+
+	for _, s := range []string{"DSN", "PWD={abc", "PWD={a}b", "=x"} {
+		if _, err := ParseConnString(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+
+	got, err := RemoveConnAttrs("DRIVER={a;b} ; x=1;uid = me;X=2", func(key string) bool { return strings.EqualFold(key, "x") })
+	if want := "DRIVER={a;b} ;uid = me;"; err != nil || got != want {
+		t.Errorf("RemoveConnAttrs: got %q, %v, want %q", got, err, want)
+	}
+}
@@ -0,0 +1,125 @@
+package odbc
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// Option is a connection attribute that Connect sets, before
+// SQLDriverConnect for the attributes that must be known at login
+// and after it for the others.
+type Option struct {
+	attr api.SQLINTEGER
+	num  uintptr
+	str  string
+	// isString selects str over num.
+	isString bool
+	// preConnect options are set before SQLDriverConnect.
+	preConnect bool
+}
+
+// LoginTimeout limits how long SQLDriverConnect waits for the server
+// (SQL_ATTR_LOGIN_TIMEOUT). It is rounded up to whole seconds; zero
+// waits forever.
+func LoginTimeout(d time.Duration) Option {
+	return Option{attr: api.SQL_ATTR_LOGIN_TIMEOUT, num: seconds(d), preConnect: true}
+}
+
+// ConnectionTimeout limits how long any request other than a query
+// waits for the server (SQL_ATTR_CONNECTION_TIMEOUT). It is rounded up
+// to whole seconds; zero waits forever.
+func ConnectionTimeout(d time.Duration) Option {
+	return Option{attr: api.SQL_ATTR_CONNECTION_TIMEOUT, num: seconds(d)}
+}
+
+// ReadOnlyMode sets the access mode to SQL_MODE_READ_ONLY or
+// SQL_MODE_READ_WRITE (SQL_ATTR_ACCESS_MODE).
+func ReadOnlyMode(b bool) Option {
+	mode := uintptr(api.SQL_MODE_READ_WRITE)
+	if b {
+		mode = uintptr(api.SQL_MODE_READ_ONLY)
+	}
+	return Option{attr: api.SQL_ATTR_ACCESS_MODE, num: mode}
+}
+
+// Catalog sets the current catalog (database) of the connection
+// (SQL_ATTR_CURRENT_CATALOG).
+func Catalog(name string) Option {
+	return Option{attr: api.SQL_ATTR_CURRENT_CATALOG, str: name, isString: true}
+}
+
+// PacketSize sets the network packet size in bytes (SQL_ATTR_PACKET_SIZE).
+func PacketSize(n int) Option {
+	return Option{attr: api.SQL_ATTR_PACKET_SIZE, num: uintptr(n), preConnect: true}
+}
+
+// Isolation sets the SQL_TXN_* transaction isolation level (SQL_ATTR_TXN_ISOLATION).
+func Isolation(level int) Option {
+	return Option{attr: api.SQL_ATTR_TXN_ISOLATION, num: uintptr(level)}
+}
+
+// AutoCommitMode turns autocommit on or off (SQL_ATTR_AUTOCOMMIT).
+func AutoCommitMode(b bool) Option {
+	mode := uintptr(api.SQL_AUTOCOMMIT_OFF)
+	if b {
+		mode = uintptr(api.SQL_AUTOCOMMIT_ON)
+	}
+	return Option{attr: api.SQL_ATTR_AUTOCOMMIT, num: mode}
+}
+
+func seconds(d time.Duration) uintptr {
+	if d <= 0 {
+		return 0
+	}
+	return uintptr((d + time.Second - 1) / time.Second)
+}
+
+// connectOptions collects the Options and the WarningFunc among the
+// Connect params.
+func connectOptions(params []interface{}) ([]Option, WarningFunc, error) {
+	var opts []Option
+	var warn WarningFunc
+	for _, p := range params {
+		switch v := p.(type) {
+		case Option:
+			opts = append(opts, v)
+		case *Option:
+			opts = append(opts, *v)
+		case []Option:
+			opts = append(opts, v...)
+		case WarningFunc:
+			warn = v
+		default:
+			return nil, nil, fmt.Errorf("odbc: unsupported Connect parameter of type %T", p)
+		}
+	}
+	return opts, warn, nil
+}
+
+// setOptions sets the options of one phase on the connection.
+func (conn *Connection) setOptions(opts []Option, preConnect bool) error {
+	h := api.SQLHDBC(conn.Dbc)
+	for _, o := range opts {
+		if o.preConnect != preConnect {
+			continue
+		}
+		var ret api.SQLRETURN
+		if o.isString {
+			w := StringToUTF16(o.str)
+			ret = api.SQLSetConnectAttr(h, o.attr, api.SQLPOINTER(unsafe.Pointer(&w[0])), api.SQLINTEGER((len(w)-1)*2))
+		} else {
+			ret = api.SQLSetConnectAttr(h, o.attr, api.SQLPOINTER(unsafe.Pointer(o.num)), api.SQL_IS_UINTEGER)
+		}
+		if IsError(ret) {
+			err := NewError("SQLSetConnectAttr", h)
+			return err
+		}
+		if err := conn.warnings.check(ret, "SQLSetConnectAttr", h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
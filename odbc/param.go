@@ -0,0 +1,492 @@
+package odbc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+const (
+	// strings and byte slices longer than this are bound as
+	// SQL_WLONGVARCHAR and SQL_LONGVARBINARY.
+	maxVarcharLen   = 4000
+	maxVarbinaryLen = 8000
+)
+
+// inParam is the SQLBindParameter description of an input value.
+type inParam struct {
+	cType   api.SQLSMALLINT
+	sqlType api.SQLSMALLINT
+	size    api.SQLULEN
+	decimal api.SQLSMALLINT
+	buf     []byte
+	ind     api.SQLLEN
+}
+
+// newInParam converts an input value to its C representation.
+// It accepts every driver.Value type, the other integer and float
+// kinds, and the odbc TimeStamp, Date and Time types.
+func newInParam(param interface{}) (*inParam, error) {
+	p := &inParam{}
+	switch v := param.(type) {
+	case nil:
+		p.cType = api.SQL_C_DEFAULT
+		p.sqlType = api.SQL_VARCHAR
+		p.size = 1
+		p.ind = api.SQL_NULL_DATA
+		return p, nil
+	case []byte:
+		if v == nil {
+			p.cType = api.SQL_C_BINARY
+			p.sqlType = api.SQL_VARBINARY
+			p.size = 1
+			p.ind = api.SQL_NULL_DATA
+			return p, nil
+		}
+		p.setBytes(v)
+		return p, nil
+	case time.Time:
+		p.setTimestamp(GotimeToTimestamp(v))
+		return p, nil
+	case TimeStamp:
+		p.setTimestamp(v)
+		return p, nil
+	case Date:
+		d := v.ToCdate()
+		p.cType = api.SQL_C_TYPE_DATE
+		p.sqlType = api.SQL_TYPE_DATE
+		p.size = 10
+		p.buf = (*[unsafe.Sizeof(d)]byte)(unsafe.Pointer(&d))[:]
+		p.ind = api.SQLLEN(len(p.buf))
+		return p, nil
+	case Time:
+		t := v.ToTimestamp().ToCtime()
+		p.cType = api.SQL_C_TYPE_TIME
+		p.sqlType = api.SQL_TYPE_TIME
+		p.size = 8
+		p.buf = (*[unsafe.Sizeof(t)]byte)(unsafe.Pointer(&t))[:]
+		p.ind = api.SQLLEN(len(p.buf))
+		return p, nil
+	}
+
+	v := reflect.ValueOf(param)
+	switch v.Kind() {
+	case reflect.Bool:
+		p.cType = api.SQL_C_BIT
+		p.sqlType = api.SQL_BIT
+		p.size = 1
+		p.buf = []byte{0}
+		if v.Bool() {
+			p.buf[0] = 1
+		}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		p.setInt32(int32(v.Int()))
+	case reflect.Int, reflect.Int64:
+		p.setInt64(v.Int())
+	case reflect.Uint8, reflect.Uint16:
+		p.setInt32(int32(v.Uint()))
+	case reflect.Uint32:
+		p.setInt64(int64(v.Uint()))
+	case reflect.Uint, reflect.Uint64:
+		n := v.Uint()
+		p.setInt64(int64(n))
+		p.cType = api.SQL_C_UBIGINT
+		if n > math.MaxInt64 {
+			// too large for a signed BIGINT column on the server.
+			p.sqlType = api.SQL_DECIMAL
+			p.size = 20
+		}
+	case reflect.Float32:
+		f := float32(v.Float())
+		p.cType = api.SQL_C_FLOAT
+		p.sqlType = api.SQL_REAL
+		p.size = 7
+		p.buf = (*[4]byte)(unsafe.Pointer(&f))[:]
+	case reflect.Float64:
+		f := v.Float()
+		p.cType = api.SQL_C_DOUBLE
+		p.sqlType = api.SQL_DOUBLE
+		p.size = 15
+		p.buf = (*[8]byte)(unsafe.Pointer(&f))[:]
+	case reflect.String:
+		p.setString(v.String())
+		return p, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, fmt.Errorf("unsupported type %T", param)
+		}
+		p.setBytes(v.Bytes())
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", param)
+	}
+	p.ind = api.SQLLEN(len(p.buf))
+	return p, nil
+}
+
+func (p *inParam) setInt32(n int32) {
+	p.cType = api.SQL_C_LONG
+	p.sqlType = api.SQL_INTEGER
+	p.size = 10
+	p.buf = (*[4]byte)(unsafe.Pointer(&n))[:]
+}
+
+func (p *inParam) setInt64(n int64) {
+	p.cType = api.SQL_C_SBIGINT
+	p.sqlType = api.SQL_BIGINT
+	p.size = 19
+	p.buf = (*[8]byte)(unsafe.Pointer(&n))[:]
+}
+
+// setString binds s as a NUL terminated UTF-16 string,
+// so that non-ASCII text reaches Unicode columns intact.
+func (p *inParam) setString(s string) {
+	w := StringToUTF16(s)
+	n := len(w) - 1
+	p.cType = api.SQL_C_WCHAR
+	p.sqlType = api.SQL_WVARCHAR
+	if n > maxVarcharLen {
+		p.sqlType = api.SQL_WLONGVARCHAR
+	}
+	p.size = api.SQLULEN(n)
+	if n == 0 {
+		p.size = 1
+	}
+	p.buf = unsafe.Slice((*byte)(unsafe.Pointer(&w[0])), len(w)*2)
+	p.ind = api.SQLLEN(n * 2)
+}
+
+func (p *inParam) setBytes(b []byte) {
+	p.cType = api.SQL_C_BINARY
+	p.sqlType = api.SQL_VARBINARY
+	if len(b) > maxVarbinaryLen {
+		p.sqlType = api.SQL_LONGVARBINARY
+	}
+	p.size = api.SQLULEN(len(b))
+	if len(b) == 0 {
+		p.size = 1
+	}
+	p.buf = b
+	p.ind = api.SQLLEN(len(b))
+}
+
+// setTimestamp binds ts with as many fractional digits as it needs.
+func (p *inParam) setTimestamp(ts TimeStamp) {
+	decimal := 0
+	if ns := ts.Fraction; ns != 0 {
+		decimal = 9
+		for ns%10 == 0 {
+			ns /= 10
+			decimal--
+		}
+	}
+	c := ts.ToCtimestamp()
+	p.cType = api.SQL_C_TYPE_TIMESTAMP
+	p.sqlType = api.SQL_TYPE_TIMESTAMP
+	p.size = 19
+	if decimal > 0 {
+		p.size = api.SQLULEN(20 + decimal)
+	}
+	p.decimal = api.SQLSMALLINT(decimal)
+	p.buf = (*[unsafe.Sizeof(c)]byte)(unsafe.Pointer(&c))[:]
+	p.ind = api.SQLLEN(len(p.buf))
+}
+
+// Out binds a stored procedure output parameter.
+// Dest must be a pointer and receives the value returned by the server
+// once the statement has been executed. If In is set the parameter is
+// bound as SQL_PARAM_INPUT_OUTPUT and the current value of Dest is sent
+// to the server as input.
+type Out struct {
+	Dest interface{}
+	In   bool
+}
+
+// ReturnValue binds the return value of a procedure called as
+// "{? = call proc(...)}"; it must be the first parameter.
+func ReturnValue(dest interface{}) Out {
+	return Out{Dest: dest}
+}
+
+type scanner interface {
+	Scan(src interface{}) error
+}
+
+// outParam is an output parameter waiting for the driver
+// to write its value into the bound buffer.
+type outParam struct {
+	*paramBuffer
+	index int
+	dest  interface{}
+	cType api.SQLSMALLINT
+}
+
+func (stmt *Statement) bindOutParam(index int, out Out) error {
+	dv := reflect.ValueOf(out.Dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("odbc: output parameter %d destination must be a non-nil pointer, got %T", index, out.Dest)
+	}
+
+	sqlType, size, decimal, _, err := stmt.GetParamType(index)
+	if err != nil || sqlType == api.SQL_UNKNOWN_TYPE {
+		// SQLDescribeParam is optional, guess from the destination.
+		sqlType, size, decimal = outParamType(dv.Elem().Type())
+	}
+
+	p := &outParam{index: index, dest: out.Dest}
+	var bufSize int
+	var columnSize api.SQLULEN
+	switch sqlType {
+	case api.SQL_BIT:
+		p.cType = api.SQL_C_BIT
+		bufSize = 1
+	case api.SQL_TINYINT, api.SQL_SMALLINT, api.SQL_INTEGER, api.SQL_BIGINT:
+		p.cType = api.SQL_C_SBIGINT
+		bufSize = 8
+	case api.SQL_REAL, api.SQL_FLOAT, api.SQL_DOUBLE, api.SQL_NUMERIC, api.SQL_DECIMAL:
+		p.cType = api.SQL_C_DOUBLE
+		bufSize = 8
+		columnSize = api.SQLULEN(size)
+	case api.SQL_TYPE_DATE, api.SQL_TYPE_TIME, api.SQL_TYPE_TIMESTAMP, api.SQL_DATETIME:
+		var ts api.SQL_TIMESTAMP_STRUCT
+		p.cType = api.SQL_C_TYPE_TIMESTAMP
+		bufSize = int(unsafe.Sizeof(ts))
+		columnSize = api.SQLULEN(size)
+	case api.SQL_BINARY, api.SQL_VARBINARY, api.SQL_LONGVARBINARY:
+		if size <= 0 || size > BUFFER_SIZE {
+			size = BUFFER_SIZE
+		}
+		p.cType = api.SQL_C_BINARY
+		bufSize = size
+		columnSize = api.SQLULEN(size)
+	default:
+		if size <= 0 || size > BUFFER_SIZE {
+			size = BUFFER_SIZE
+		}
+		// +1 : for null-termination character
+		// *2 : wchars take 2 bytes each
+		p.cType = api.SQL_C_WCHAR
+		bufSize = (size + 1) * 2
+		columnSize = api.SQLULEN(size)
+	}
+
+	p.paramBuffer, err = newParamBuffer(bufSize)
+	if err != nil {
+		return err
+	}
+	ioType := api.SQLSMALLINT(api.SQL_PARAM_OUTPUT)
+	*p.ind = api.SQL_NULL_DATA
+	if out.In {
+		ioType = api.SQL_PARAM_INPUT_OUTPUT
+		if err := p.put(dv.Elem()); err != nil {
+			p.free()
+			return err
+		}
+	}
+
+	ret := api.SQLBindParameter(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(index), ioType,
+		p.cType, api.SQLSMALLINT(sqlType), columnSize, api.SQLSMALLINT(decimal),
+		p.ptr(), api.SQLLEN(len(p.buf)), p.ind)
+	if IsError(ret) {
+		err := NewError("SQLBindParameter", api.SQLHSTMT(stmt.handle))
+		p.free()
+		return err
+	}
+	stmt.setParamBuffer(index, p.paramBuffer)
+	stmt.outParams = append(stmt.outParams, p)
+	return nil
+}
+
+// outParamType maps a Go destination type to the SQL type, size
+// and decimal digits used when the driver cannot describe the parameter.
+func outParamType(t reflect.Type) (sqlType int, size int, decimal int) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return api.SQL_BIT, 1, 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return api.SQL_BIGINT, 19, 0
+	case reflect.Float32, reflect.Float64:
+		return api.SQL_DOUBLE, 15, 0
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return api.SQL_VARBINARY, BUFFER_SIZE, 0
+		}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return api.SQL_TYPE_TIMESTAMP, 29, 9
+		}
+	}
+	return api.SQL_WVARCHAR, BUFFER_SIZE, 0
+}
+
+// put writes the input value of an input/output parameter into its buffer.
+func (p *outParam) put(v reflect.Value) error {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		*p.ind = api.SQL_NULL_DATA
+		return nil
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch p.cType {
+	case api.SQL_C_BIT:
+		if v.Kind() != reflect.Bool {
+			break
+		}
+		p.buf[0] = 0
+		if v.Bool() {
+			p.buf[0] = 1
+		}
+		*p.ind = 1
+		return nil
+	case api.SQL_C_SBIGINT:
+		var n int64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = v.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = int64(v.Uint())
+		default:
+			return fmt.Errorf("odbc: cannot use %s as input of integer parameter %d", v.Type(), p.index)
+		}
+		*(*int64)(unsafe.Pointer(&p.buf[0])) = n
+		*p.ind = 8
+		return nil
+	case api.SQL_C_DOUBLE:
+		var f float64
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f = v.Float()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(v.Int())
+		default:
+			return fmt.Errorf("odbc: cannot use %s as input of float parameter %d", v.Type(), p.index)
+		}
+		*(*float64)(unsafe.Pointer(&p.buf[0])) = f
+		*p.ind = 8
+		return nil
+	case api.SQL_C_TYPE_TIMESTAMP:
+		t, ok := v.Interface().(time.Time)
+		if !ok {
+			break
+		}
+		*(*api.SQL_TIMESTAMP_STRUCT)(unsafe.Pointer(&p.buf[0])) = GotimeToTimestamp(t).ToCtimestamp()
+		*p.ind = api.SQLLEN(len(p.buf))
+		return nil
+	case api.SQL_C_BINARY:
+		if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		n := copy(p.buf, v.Bytes())
+		*p.ind = api.SQLLEN(n)
+		return nil
+	case api.SQL_C_WCHAR:
+		s := StringToUTF16(fmt.Sprint(v.Interface()))
+		if len(s)*2 > len(p.buf) {
+			return fmt.Errorf("odbc: input value of parameter %d exceeds %d characters", p.index, len(p.buf)/2-1)
+		}
+		for i, c := range s {
+			*(*uint16)(unsafe.Pointer(&p.buf[i*2])) = c
+		}
+		*p.ind = api.SQLLEN((len(s) - 1) * 2)
+		return nil
+	}
+	return fmt.Errorf("odbc: cannot use %s as input of parameter %d", v.Type(), p.index)
+}
+
+// value converts the buffer written by the driver to a Go value.
+func (p *outParam) value() interface{} {
+	if *p.ind == api.SQL_NULL_DATA {
+		return nil
+	}
+	switch p.cType {
+	case api.SQL_C_BIT:
+		return p.buf[0] != 0
+	case api.SQL_C_SBIGINT:
+		return *(*int64)(unsafe.Pointer(&p.buf[0]))
+	case api.SQL_C_DOUBLE:
+		return *(*float64)(unsafe.Pointer(&p.buf[0]))
+	case api.SQL_C_TYPE_TIMESTAMP:
+		ts := *(*api.SQL_TIMESTAMP_STRUCT)(unsafe.Pointer(&p.buf[0]))
+		return time.Date(int(ts.Year), time.Month(ts.Month), int(ts.Day), int(ts.Hour), int(ts.Minute), int(ts.Second), int(ts.Fraction), time.UTC)
+	case api.SQL_C_BINARY:
+		n := int(*p.ind)
+		if n < 0 || n > len(p.buf) {
+			n = len(p.buf)
+		}
+		b := make([]byte, n)
+		copy(b, p.buf)
+		return b
+	}
+	n := int(*p.ind) / 2
+	if n < 0 || n > len(p.buf)/2 {
+		n = len(p.buf) / 2
+	}
+	s := make([]uint16, n)
+	for i := range s {
+		s[i] = *(*uint16)(unsafe.Pointer(&p.buf[i*2]))
+	}
+	return UTF16ToString(s)
+}
+
+// storeOutParams copies the output parameters into their destinations.
+func (stmt *Statement) storeOutParams() error {
+	for _, p := range stmt.outParams {
+		if err := assign(p.dest, p.value()); err != nil {
+			return fmt.Errorf("odbc: output parameter %d: %v", p.index, err)
+		}
+	}
+	return nil
+}
+
+// assign stores v into the pointer dest, converting between
+// the numeric, string and time types returned by the driver.
+func assign(dest interface{}, v interface{}) error {
+	if s, ok := dest.(scanner); ok {
+		return s.Scan(v)
+	}
+	dv := reflect.ValueOf(dest).Elem()
+	if v == nil {
+		dv.Set(reflect.Zero(dv.Type()))
+		return nil
+	}
+	sv := reflect.ValueOf(v)
+	if dv.Kind() == reflect.Ptr {
+		if sv.Type().AssignableTo(dv.Type().Elem()) || sv.Type().ConvertibleTo(dv.Type().Elem()) {
+			pv := reflect.New(dv.Type().Elem())
+			if err := assign(pv.Interface(), v); err != nil {
+				return err
+			}
+			dv.Set(pv)
+			return nil
+		}
+	}
+	switch {
+	case sv.Type().AssignableTo(dv.Type()):
+		dv.Set(sv)
+	case dv.Kind() == reflect.String:
+		dv.SetString(fmt.Sprint(v))
+	case dv.Kind() == reflect.Bool && sv.Kind() == reflect.Int64:
+		dv.SetBool(sv.Int() != 0)
+	case isNumber(dv.Kind()) && isNumber(sv.Kind()):
+		dv.Set(sv.Convert(dv.Type()))
+	default:
+		return fmt.Errorf("cannot assign %T to %s", v, dv.Type())
+	}
+	return nil
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package odbc
+
+/*
+#include <stdlib.h>
+#include <string.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// ErrNotScrollable is returned by the cursor positioning methods of a
+// statement that was not prepared with ScrollableCursor.
+var ErrNotScrollable = errors.New("odbc: cursor is not scrollable")
+
+// The positioning methods below move a scrollable cursor with
+// SQLFetchScroll and return the row they land on, or nil if the cursor
+// moved before the first or after the last row. The statement must be
+// prepared with ScrollableCursor. FetchOne may be mixed with them and
+// continues from the current row.
+
+// Next moves the cursor to the next row.
+func (stmt *Statement) Next() (*Row, error) {
+	return stmt.scroll(api.SQL_FETCH_NEXT, 0)
+}
+
+// First moves the cursor to the first row.
+func (stmt *Statement) First() (*Row, error) {
+	return stmt.scroll(api.SQL_FETCH_FIRST, 0)
+}
+
+// Last moves the cursor to the last row.
+func (stmt *Statement) Last() (*Row, error) {
+	return stmt.scroll(api.SQL_FETCH_LAST, 0)
+}
+
+// Prior moves the cursor to the previous row.
+func (stmt *Statement) Prior() (*Row, error) {
+	return stmt.scroll(api.SQL_FETCH_PRIOR, 0)
+}
+
+// Absolute moves the cursor to row n, counting from 1. A negative n
+// counts back from the end, so -1 is the last row.
+func (stmt *Statement) Absolute(n int) (*Row, error) {
+	return stmt.scroll(api.SQL_FETCH_ABSOLUTE, n)
+}
+
+// Relative moves the cursor n rows forward, or backward if n is negative.
+func (stmt *Statement) Relative(n int) (*Row, error) {
+	return stmt.scroll(api.SQL_FETCH_RELATIVE, n)
+}
+
+// SetBookmarks turns variable-length bookmarks on or off
+// (SQL_ATTR_USE_BOOKMARKS), which Bookmark and FetchBookmark need.
+// Most drivers only accept it before the statement is prepared; see
+// the Bookmarks option.
+func (stmt *Statement) SetBookmarks(b bool) error {
+	v := api.SQL_UB_OFF
+	if b {
+		v = api.SQL_UB_VARIABLE
+	}
+	return stmt.setStmtAttrUint(api.SQL_ATTR_USE_BOOKMARKS, v)
+}
+
+// Bookmark returns the bookmark of the current row.
+func (stmt *Statement) Bookmark() ([]byte, error) {
+	// column 0 holds the bookmark.
+	return stmt.readAll(-1, api.SQL_C_VARBOOKMARK, 0)
+}
+
+// FetchBookmark moves the cursor offset rows from the row of bookmark.
+func (stmt *Statement) FetchBookmark(bookmark []byte, offset int) (*Row, error) {
+	if len(bookmark) == 0 {
+		return nil, errors.New("odbc: empty bookmark")
+	}
+	// the driver reads the bookmark through a pointer kept in the statement.
+	mem := C.malloc(C.size_t(len(bookmark)))
+	defer C.free(mem)
+	C.memcpy(mem, unsafe.Pointer(&bookmark[0]), C.size_t(len(bookmark)))
+
+	h := api.SQLHSTMT(stmt.handle)
+	ret := api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_FETCH_BOOKMARK_PTR, uintptr(mem), 0)
+	if IsError(ret) {
+		err := NewError("SQLSetStmtAttr", h)
+		return nil, err
+	}
+	defer api.SQLSetStmtUIntPtrAttr(h, api.SQL_ATTR_FETCH_BOOKMARK_PTR, 0, 0)
+	return stmt.scroll(api.SQL_FETCH_BOOKMARK, offset)
+}
+
+func (stmt *Statement) scroll(orientation api.SQLSMALLINT, offset int) (*Row, error) {
+	if !stmt.scrollable {
+		return nil, ErrNotScrollable
+	}
+	if _, err := stmt.columns(); err != nil {
+		return nil, err
+	}
+	stmt.rowByRow = true
+
+	h := api.SQLHSTMT(stmt.handle)
+	ret := api.SQLFetchScroll(h, orientation, api.SQLLEN(offset))
+	if ret == api.SQL_NO_DATA {
+		return nil, nil
+	}
+	if IsError(ret) {
+		err := NewError("SQLFetchScroll", h)
+		return nil, err
+	}
+	if err := stmt.warnings.check(ret, "SQLFetchScroll", h); err != nil {
+		return nil, err
+	}
+	return stmt.row()
+}
+
+// StatementOption configures a statement before Prepare sends its SQL
+// to the driver. Drivers reject changes to the cursor type, the
+// concurrency and the use of bookmarks once a statement is prepared.
+type StatementOption func(*Statement) error
+
+// ScrollableCursor makes the statement cursor scrollable.
+func ScrollableCursor() StatementOption {
+	return func(stmt *Statement) error {
+		return stmt.SetScrollable(true)
+	}
+}
+
+// Bookmarks turns on variable-length bookmarks.
+func Bookmarks() StatementOption {
+	return func(stmt *Statement) error {
+		return stmt.SetBookmarks(true)
+	}
+}
+
+// Cursor sets the SQL_CURSOR_* cursor type and the SQL_CONCUR_* concurrency.
+func Cursor(cursorType, concurrency int) StatementOption {
+	return func(stmt *Statement) error {
+		if err := stmt.SetCursorType(cursorType); err != nil {
+			return err
+		}
+		return stmt.SetConcurrency(concurrency)
+	}
+}
@@ -0,0 +1,145 @@
+package odbc
+
+import (
+	"strings"
+
+	"github.com/jooita/sql/api"
+)
+
+// DriverInfo is a driver installed in the driver manager.
+type DriverInfo struct {
+	Name string
+	// Attributes holds the keywords of the driver's odbcinst.ini
+	// section, such as Driver and Setup.
+	Attributes map[string]string
+}
+
+// DataSource is a configured data source name.
+type DataSource struct {
+	Name        string
+	Description string
+}
+
+// DataSourceScope selects the data sources DataSources lists.
+type DataSourceScope int
+
+const (
+	AllDataSources DataSourceScope = iota
+	UserDataSources
+	SystemDataSources
+)
+
+// Drivers lists the installed drivers using SQLDrivers.
+func Drivers() ([]DriverInfo, error) {
+	var drivers []DriverInfo
+	err := enumerate("SQLDrivers", api.SQL_FETCH_FIRST, func(dir api.SQLUSMALLINT, name, attrs []uint16, n1, n2 *api.SQLSMALLINT) api.SQLRETURN {
+		return api.SQLDrivers(api.SQLHENV(Genv), dir, (*api.SQLWCHAR)(&name[0]), api.SQLSMALLINT(len(name)), n1, (*api.SQLWCHAR)(&attrs[0]), api.SQLSMALLINT(len(attrs)), n2)
+	}, func(name, attrs []uint16) {
+		drivers = append(drivers, DriverInfo{Name: UTF16ToString(name), Attributes: driverAttributes(attrs)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drivers, nil
+}
+
+// DataSources lists the data source names of the given scope using SQLDataSources.
+func DataSources(scope DataSourceScope) ([]DataSource, error) {
+	first := api.SQLUSMALLINT(api.SQL_FETCH_FIRST)
+	switch scope {
+	case UserDataSources:
+		first = api.SQL_FETCH_FIRST_USER
+	case SystemDataSources:
+		first = api.SQL_FETCH_FIRST_SYSTEM
+	}
+	var sources []DataSource
+	err := enumerate("SQLDataSources", first, func(dir api.SQLUSMALLINT, name, desc []uint16, n1, n2 *api.SQLSMALLINT) api.SQLRETURN {
+		return api.SQLDataSources(api.SQLHENV(Genv), dir, (*api.SQLWCHAR)(&name[0]), api.SQLSMALLINT(len(name)), n1, (*api.SQLWCHAR)(&desc[0]), api.SQLSMALLINT(len(desc)), n2)
+	}, func(name, desc []uint16) {
+		sources = append(sources, DataSource{Name: UTF16ToString(name), Description: UTF16ToString(desc)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// enumerate runs an SQLDrivers-style enumeration from first to SQL_NO_DATA.
+// If an entry does not fit the buffers it starts over with larger ones.
+// An entry longer than the largest buffers, of 1<<15-1 characters, is
+// kept truncated.
+func enumerate(apiName string, first api.SQLUSMALLINT,
+	call func(dir api.SQLUSMALLINT, a, b []uint16, na, nb *api.SQLSMALLINT) api.SQLRETURN,
+	add func(a, b []uint16)) error {
+	sizeA, sizeB := INFO_BUFFER_LEN, 4*INFO_BUFFER_LEN
+	for {
+		a, b := make([]uint16, sizeA), make([]uint16, sizeB)
+		var entries [][2][]uint16
+		truncated := false
+		for dir := first; ; dir = api.SQL_FETCH_NEXT {
+			var na, nb api.SQLSMALLINT
+			ret := call(dir, a, b, &na, &nb)
+			if ret == api.SQL_NO_DATA {
+				break
+			}
+			if IsError(ret) {
+				err := NewError(apiName, api.SQLHENV(Genv))
+				return err
+			}
+			if int(na) >= sizeA || int(nb) >= sizeB {
+				grewA, grewB := growEnumBuffer(&sizeA, na), growEnumBuffer(&sizeB, nb)
+				if grewA || grewB {
+					truncated = true
+					break
+				}
+				if int(na) >= sizeA {
+					na = api.SQLSMALLINT(sizeA - 1)
+				}
+				if int(nb) >= sizeB {
+					nb = api.SQLSMALLINT(sizeB - 1)
+				}
+			}
+			entries = append(entries, [2][]uint16{append([]uint16(nil), a[:na]...), append([]uint16(nil), b[:nb]...)})
+		}
+		if truncated {
+			continue
+		}
+		for _, e := range entries {
+			add(e[0], e[1])
+		}
+		return nil
+	}
+}
+
+// growEnumBuffer makes *size fit n characters and the null terminator,
+// within the SQLSMALLINT buffer length. It reports whether *size changed.
+func growEnumBuffer(size *int, n api.SQLSMALLINT) bool {
+	const max = 1<<15 - 1
+	if int(n) < *size || *size >= max {
+		return false
+	}
+	*size = int(n) + 1
+	if *size > max {
+		*size = max
+	}
+	return true
+}
+
+// driverAttributes parses the "key=value\0key=value\0\0" list of SQLDrivers.
+func driverAttributes(attrs []uint16) map[string]string {
+	m := make(map[string]string)
+	start := 0
+	for i := 0; i <= len(attrs); i++ {
+		if i < len(attrs) && attrs[i] != 0 {
+			continue
+		}
+		if i > start {
+			kv := UTF16ToString(attrs[start:i])
+			if j := strings.Index(kv, "="); j > 0 {
+				m[kv[:j]] = kv[j+1:]
+			}
+		}
+		start = i + 1
+	}
+	return m
+}
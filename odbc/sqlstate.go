@@ -0,0 +1,75 @@
+package odbc
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+// Errors classifying an *Error by the SQLSTATEs of its diagnostic
+// records, for use with errors.Is. errors.As still gives the *Error
+// with the full records and native error codes.
+var (
+	// ErrConstraintViolation is an integrity constraint violation
+	// (class 23), such as a duplicate key or a missing foreign key.
+	ErrConstraintViolation = errors.New("odbc: integrity constraint violation")
+	// ErrSerializationFailure is a transaction rolled back by the
+	// server because of a deadlock or a serialization failure (40001,
+	// 40P01). The transaction can be retried.
+	ErrSerializationFailure = errors.New("odbc: serialization failure")
+	// ErrTimeout is a query or connection timeout (HYT00, HYT01).
+	ErrTimeout = errors.New("odbc: timeout expired")
+	// ErrSyntax is a syntax error or access violation (class 42).
+	ErrSyntax = errors.New("odbc: syntax error or access violation")
+	// ErrConnection is a connection exception (class 08).
+	ErrConnection = errors.New("odbc: connection failure")
+	// ErrCancelled is an operation cancelled by SQLCancel (HY008).
+	ErrCancelled = errors.New("odbc: operation cancelled")
+)
+
+// sqlStates maps the classified errors to the SQLSTATEs they match.
+// A two character state matches the whole class.
+var sqlStates = map[error][]string{
+	ErrConstraintViolation:  {"23"},
+	ErrSerializationFailure: {"40001", "40P01"},
+	ErrTimeout:              {"HYT00", "HYT01"},
+	ErrSyntax:               {"42"},
+	ErrConnection:           {"08"},
+	ErrCancelled:            {"HY008"},
+	// a lost connection (08S01) lets database/sql retry on a new one.
+	driver.ErrBadConn: {"08S01"},
+}
+
+// Is reports whether target classifies any diagnostic record of e.
+func (e *Error) Is(target error) bool {
+	states, ok := sqlStates[target]
+	if !ok {
+		return false
+	}
+	for _, r := range e.Diag {
+		for _, s := range states {
+			if strings.HasPrefix(r.State, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// State returns the SQLSTATE of the first diagnostic record, or ""
+// if there is none.
+func (e *Error) State() string {
+	if len(e.Diag) == 0 {
+		return ""
+	}
+	return e.Diag[0].State
+}
+
+// NativeError returns the driver specific error code of the first
+// diagnostic record, or 0 if there is none.
+func (e *Error) NativeError() int {
+	if len(e.Diag) == 0 {
+		return 0
+	}
+	return e.Diag[0].NativeError
+}
@@ -0,0 +1,118 @@
+package odbc
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// QueryTimeout returns how long the driver waits for a statement to
+// execute before returning to the application (SQL_ATTR_QUERY_TIMEOUT).
+// Zero means no timeout.
+func (stmt *Statement) QueryTimeout() (time.Duration, error) {
+	v, err := stmt.getStmtAttrUint(api.SQL_ATTR_QUERY_TIMEOUT)
+	return time.Duration(v) * time.Second, err
+}
+
+// SetQueryTimeout sets SQL_ATTR_QUERY_TIMEOUT. d is rounded up to whole
+// seconds; zero disables the timeout.
+func (stmt *Statement) SetQueryTimeout(d time.Duration) error {
+	return stmt.setStmtAttrUint(api.SQL_ATTR_QUERY_TIMEOUT, seconds(d))
+}
+
+// MaxRows returns the maximum number of rows a query returns
+// (SQL_ATTR_MAX_ROWS). Zero means all rows.
+func (stmt *Statement) MaxRows() (int, error) {
+	v, err := stmt.getStmtAttrUint(api.SQL_ATTR_MAX_ROWS)
+	return int(v), err
+}
+
+// SetMaxRows sets SQL_ATTR_MAX_ROWS; zero returns all rows.
+func (stmt *Statement) SetMaxRows(n int) error {
+	return stmt.setStmtAttrUint(api.SQL_ATTR_MAX_ROWS, uintptr(n))
+}
+
+// CursorType returns the SQL_CURSOR_* cursor type (SQL_ATTR_CURSOR_TYPE).
+func (stmt *Statement) CursorType() (int, error) {
+	v, err := stmt.getStmtAttrUint(api.SQL_ATTR_CURSOR_TYPE)
+	return int(v), err
+}
+
+// SetCursorType sets the SQL_CURSOR_* cursor type used by the next
+// execution. Drivers may substitute a different type, which CursorType
+// reports afterwards. Most drivers only accept it before the statement
+// is prepared; see the Cursor option.
+func (stmt *Statement) SetCursorType(cursorType int) error {
+	return stmt.setStmtAttrUint(api.SQL_ATTR_CURSOR_TYPE, uintptr(cursorType))
+}
+
+// Concurrency returns the SQL_CONCUR_* cursor concurrency (SQL_ATTR_CONCURRENCY).
+func (stmt *Statement) Concurrency() (int, error) {
+	v, err := stmt.getStmtAttrUint(api.SQL_ATTR_CONCURRENCY)
+	return int(v), err
+}
+
+// SetConcurrency sets the SQL_CONCUR_* cursor concurrency.
+func (stmt *Statement) SetConcurrency(concurrency int) error {
+	return stmt.setStmtAttrUint(api.SQL_ATTR_CONCURRENCY, uintptr(concurrency))
+}
+
+// Scrollable reports whether the cursor is SQL_SCROLLABLE
+// (SQL_ATTR_CURSOR_SCROLLABLE).
+func (stmt *Statement) Scrollable() (bool, error) {
+	v, err := stmt.getStmtAttrUint(api.SQL_ATTR_CURSOR_SCROLLABLE)
+	return uintptr(v) == api.SQL_SCROLLABLE, err
+}
+
+// SetScrollable switches SQL_ATTR_CURSOR_SCROLLABLE between
+// SQL_SCROLLABLE and SQL_NONSCROLLABLE.
+func (stmt *Statement) SetScrollable(b bool) error {
+	v := api.SQL_NONSCROLLABLE
+	if b {
+		v = api.SQL_SCROLLABLE
+	}
+	if err := stmt.setStmtAttrUint(api.SQL_ATTR_CURSOR_SCROLLABLE, v); err != nil {
+		return err
+	}
+	stmt.scrollable = b
+	return nil
+}
+
+// NoScan reports whether the driver skips scanning SQL strings for
+// escape sequences (SQL_ATTR_NOSCAN).
+func (stmt *Statement) NoScan() (bool, error) {
+	v, err := stmt.getStmtAttrUint(api.SQL_ATTR_NOSCAN)
+	return uintptr(v) == api.SQL_NOSCAN_ON, err
+}
+
+// SetNoScan switches SQL_ATTR_NOSCAN between SQL_NOSCAN_ON and SQL_NOSCAN_OFF.
+func (stmt *Statement) SetNoScan(b bool) error {
+	v := api.SQL_NOSCAN_OFF
+	if b {
+		v = api.SQL_NOSCAN_ON
+	}
+	return stmt.setStmtAttrUint(api.SQL_ATTR_NOSCAN, v)
+}
+
+// getStmtAttrUint reads a SQLULEN statement attribute.
+func (stmt *Statement) getStmtAttrUint(attr api.SQLINTEGER) (api.SQLULEN, error) {
+	var v api.SQLULEN
+	h := api.SQLHSTMT(stmt.handle)
+	ret := api.SQLGetStmtAttr(h, attr, api.SQLPOINTER(unsafe.Pointer(&v)), 0, nil)
+	if IsError(ret) {
+		err := NewError("SQLGetStmtAttr", h)
+		return 0, err
+	}
+	return v, nil
+}
+
+func (stmt *Statement) setStmtAttrUint(attr api.SQLINTEGER, v uintptr) error {
+	h := api.SQLHSTMT(stmt.handle)
+	ret := api.SQLSetStmtUIntPtrAttr(h, attr, v, 0)
+	if IsError(ret) {
+		err := NewError("SQLSetStmtAttr", h)
+		return err
+	}
+	return stmt.warnings.check(ret, "SQLSetStmtAttr", h)
+}
@@ -0,0 +1,180 @@
+package odbc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/jooita/sql/api"
+)
+
+// PUT_DATA_CHUNK_SIZE is the number of bytes read from a Stream
+// and sent with each SQLPutData call.
+const PUT_DATA_CHUNK_SIZE = 64 * 1024
+
+// Stream is a parameter whose value is read from R while the statement
+// executes and sent to the driver in chunks (data-at-execution), so
+// large BLOB or CLOB values never have to be held in memory.
+type Stream struct {
+	R io.Reader
+	// Length is the total number of bytes R yields, or -1 if unknown.
+	// Drivers reporting SQL_NEED_LONG_DATA_LEN require it for binary data.
+	Length int64
+	// Text sends the UTF-8 content of R as character data
+	// (SQL_WLONGVARCHAR) instead of binary data (SQL_LONGVARBINARY).
+	Text bool
+}
+
+// streamParam is a Stream bound to a parameter marker. The address of
+// its buffer is the token SQLParamData returns when the driver asks
+// for the parameter's data.
+type streamParam struct {
+	*paramBuffer
+	index int
+	s     Stream
+	// consumed is set once R has been read by an execution.
+	consumed bool
+}
+
+func (stmt *Statement) bindStream(index int, s Stream) error {
+	if s.R == nil {
+		return fmt.Errorf("odbc: stream parameter %d has no reader", index)
+	}
+	b, err := newParamBuffer(1)
+	if err != nil {
+		return err
+	}
+	p := &streamParam{paramBuffer: b, index: index, s: s}
+
+	cType := api.SQLSMALLINT(api.SQL_C_BINARY)
+	sqlType := api.SQLSMALLINT(api.SQL_LONGVARBINARY)
+	var columnSize api.SQLULEN
+	*p.ind = api.SQL_DATA_AT_EXEC
+	if s.Text {
+		cType = api.SQL_C_WCHAR
+		sqlType = api.SQL_WLONGVARCHAR
+	} else if s.Length >= 0 {
+		columnSize = api.SQLULEN(s.Length)
+		*p.ind = api.SQL_LEN_DATA_AT_EXEC_OFFSET - api.SQLLEN(s.Length)
+	}
+
+	ret := api.SQLBindParameter(api.SQLHSTMT(stmt.handle), api.SQLUSMALLINT(index), api.SQL_PARAM_INPUT,
+		cType, sqlType, columnSize, 0, p.ptr(), 0, p.ind)
+	if IsError(ret) {
+		err := NewError("SQLBindParameter", api.SQLHSTMT(stmt.handle))
+		p.free()
+		return err
+	}
+	stmt.setParamBuffer(index, p.paramBuffer)
+	stmt.streams = append(stmt.streams, p)
+	return nil
+}
+
+// checkStreams fails if a bound stream was read by a previous
+// execution, since its reader cannot be sent again.
+func (stmt *Statement) checkStreams() error {
+	for _, p := range stmt.streams {
+		if p.consumed {
+			return fmt.Errorf("odbc: stream parameter %d was already sent; bind a new Stream to execute again", p.index)
+		}
+	}
+	return nil
+}
+
+// putData answers the SQL_NEED_DATA requests of SQLExecute by sending
+// the streams the driver asks for. It returns the result of the final
+// SQLParamData call, which is the result of the execution.
+func (stmt *Statement) putData() (api.SQLRETURN, error) {
+	for {
+		var token api.SQLPOINTER
+		ret := api.SQLParamData(api.SQLHSTMT(stmt.handle), &token)
+		if ret != api.SQL_NEED_DATA {
+			return ret, nil
+		}
+		var p *streamParam
+		for _, sp := range stmt.streams {
+			if sp.ptr() == token {
+				p = sp
+				break
+			}
+		}
+		if p == nil {
+			stmt.Cancel()
+			return ret, errors.New("odbc: driver requested data for an unknown parameter")
+		}
+		if err := p.send(stmt); err != nil {
+			stmt.Cancel()
+			return ret, err
+		}
+	}
+}
+
+// send reads the stream to the end, calling SQLPutData for every chunk.
+func (p *streamParam) send(stmt *Statement) error {
+	p.consumed = true
+	chunk := make([]byte, PUT_DATA_CHUNK_SIZE)
+	var pending []byte
+	sent := false
+	for {
+		n, rerr := p.s.R.Read(chunk)
+		if n > 0 {
+			var err error
+			if p.s.Text {
+				pending, err = p.putText(stmt, append(pending, chunk[:n]...))
+			} else {
+				err = put(stmt, unsafe.Pointer(&chunk[0]), n)
+			}
+			if err != nil {
+				return err
+			}
+			sent = true
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return fmt.Errorf("odbc: reading stream parameter %d: %v", p.index, rerr)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("odbc: stream parameter %d is not valid UTF-8", p.index)
+	}
+	if !sent {
+		// an empty value still needs one SQLPutData call.
+		return put(stmt, unsafe.Pointer(&chunk[0]), 0)
+	}
+	return nil
+}
+
+// putText sends the complete UTF-8 sequences of b as UTF-16 and returns
+// the bytes of a rune split across reads, to be prepended to the next chunk.
+func (p *streamParam) putText(stmt *Statement, b []byte) ([]byte, error) {
+	end := len(b)
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	w := utf16.Encode([]rune(string(b[:end])))
+	if len(w) > 0 {
+		if err := put(stmt, unsafe.Pointer(&w[0]), len(w)*2); err != nil {
+			return nil, err
+		}
+	}
+	return append([]byte(nil), b[end:]...), nil
+}
+
+func put(stmt *Statement, data unsafe.Pointer, n int) error {
+	ret := api.SQLPutData(api.SQLHSTMT(stmt.handle), api.SQLPOINTER(data), api.SQLLEN(n))
+	if IsError(ret) {
+		err := NewError("SQLPutData", api.SQLHSTMT(stmt.handle))
+		return err
+	}
+	return nil
+}
@@ -77,6 +77,13 @@ func (timestamp TimeStamp) ToCtime() (data api.SQL_TIME_STRUCT) {
 	return
 }
 
+func (d Date) ToCdate() (data api.SQL_DATE_STRUCT) {
+	data.Year = api.SQLSMALLINT(d.Year)
+	data.Month = api.SQLUSMALLINT(d.Month)
+	data.Day = api.SQLUSMALLINT(d.Day)
+	return
+}
+
 func GotimeToTimestamp(parseTime time.Time) TimeStamp {
 	timestamp := TimeStamp{}
 	timestamp.Year = (parseTime.Year())
@@ -0,0 +1,99 @@
+package odbc
+
+import (
+	"strings"
+
+	"github.com/jooita/sql/api"
+)
+
+// MAX_WARNINGS limits the warnings kept by a Connection or a Statement;
+// later ones are still passed to the WarningFunc.
+const MAX_WARNINGS = 64
+
+// WarningFunc is called with the diagnostic records of every call that
+// returns SQL_SUCCESS_WITH_INFO. If it returns an error, the call
+// fails with that error. It is set with Connection.SetWarningFunc, or
+// passed to Connect to also see the warnings of connecting.
+type WarningFunc func(apiName string, warnings []DiagRecord) error
+
+// StrictWarnings returns a WarningFunc that turns the warnings with
+// one of states into an *Error. A two character state matches the
+// whole class, so "01" fails on any warning.
+func StrictWarnings(states ...string) WarningFunc {
+	return func(apiName string, warnings []DiagRecord) error {
+		for _, w := range warnings {
+			for _, s := range states {
+				if strings.HasPrefix(w.State, s) {
+					return &Error{APIName: apiName, Diag: warnings}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// warnings collects the diagnostic records of SQL_SUCCESS_WITH_INFO.
+type warnings struct {
+	list []DiagRecord
+	fn   WarningFunc
+}
+
+// check records the warnings of ret, the result of apiName on handle.
+func (w *warnings) check(ret api.SQLRETURN, apiName string, handle interface{}) error {
+	if ret != api.SQL_SUCCESS_WITH_INFO {
+		return nil
+	}
+	h, ht, err := ToHandleAndType(handle)
+	if err != nil {
+		return err
+	}
+	recs, err := diagRecords(ht, h)
+	if err != nil || len(recs) == 0 {
+		return err
+	}
+	if n := MAX_WARNINGS - len(w.list); n > 0 {
+		if n > len(recs) {
+			n = len(recs)
+		}
+		w.list = append(w.list, recs[:n]...)
+	}
+	if w.fn != nil {
+		return w.fn(apiName, recs)
+	}
+	return nil
+}
+
+// Warnings returns the warnings of the connection since it was opened
+// or ClearWarnings was called.
+func (conn *Connection) Warnings() []DiagRecord {
+	return conn.warnings.list
+}
+
+// ClearWarnings forgets the warnings of the connection.
+func (conn *Connection) ClearWarnings() {
+	conn.warnings.list = nil
+}
+
+// SetWarningFunc sets the WarningFunc of the connection and of the
+// statements created on it afterwards.
+func (conn *Connection) SetWarningFunc(fn WarningFunc) {
+	conn.warnings.fn = fn
+}
+
+// Warnings returns the warnings of the last execution of the
+// statement and of fetching its results. Warnings of setting statement
+// attributes, such as 01S02 (option value changed), are dropped by the
+// execution but still reach the WarningFunc.
+func (stmt *Statement) Warnings() []DiagRecord {
+	return stmt.warnings.list
+}
+
+// ClearWarnings forgets the warnings of the statement.
+func (stmt *Statement) ClearWarnings() {
+	stmt.warnings.list = nil
+}
+
+// SetWarningFunc sets the WarningFunc of the statement.
+func (stmt *Statement) SetWarningFunc(fn WarningFunc) {
+	stmt.warnings.fn = fn
+}
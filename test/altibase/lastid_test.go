@@ -0,0 +1,39 @@
+package altibase
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	odbcdriver "github.com/jooita/sql/driver"
+)
+
+func TestExec_LastInsertId(t *testing.T) {
+	db := sql.OpenDB(odbcdriver.NewConnector(odbcdriver.Config{
+		DSN: fmt.Sprintf("DSN=%s;", *dsn),
+		LastInsertId: odbcdriver.SequenceCurrval(func(table string) string {
+			return table + "_seq"
+		}),
+	}))
+	defer db.Close()
+
+	seq := *table + "_seq"
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	db.Exec(fmt.Sprintf("drop sequence %s", seq))
+	if _, err := db.Exec(fmt.Sprintf("create sequence %s start with 10", seq)); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Exec(fmt.Sprintf("drop sequence %s", seq))
+	_, err := db.Exec(fmt.Sprintf("create table %s (id int, b varchar(20))", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := db.Exec(fmt.Sprintf("insert into %s values (%s.nextval, ?)", *table, seq), "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := r.LastInsertId(); err != nil || id != 10 {
+		t.Errorf("got %d, %v, want 10", id, err)
+	}
+}
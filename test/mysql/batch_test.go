@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestInsert_ExecBatch(t *testing.T) {
+	conn := fmt.Sprintf("DSN=%s;", *dsn)
+
+	db, err := sql.Open("odbc", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	_, err = db.Exec(fmt.Sprintf("create table %s (a int, b varchar(20))", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 1000
+	ids := make([]interface{}, n)
+	names := make([]interface{}, n)
+	for i := range ids {
+		ids[i] = i
+		names[i] = fmt.Sprintf("name %d", i)
+	}
+	names[7] = nil
+
+	c, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	var r *odbc.BatchResult
+	err = c.Raw(func(dc interface{}) error {
+		b := dc.(interface {
+			ExecBatch(string, [][]interface{}) (*odbc.BatchResult, error)
+		})
+		var err error
+		r, err = b.ExecBatch(fmt.Sprintf("insert into %s values (?, ?)", *table), [][]interface{}{ids, names})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Processed != n || r.RowsAffected != n || len(r.Failed()) != 0 {
+		t.Errorf("got processed %d, affected %d, failed %v", r.Processed, r.RowsAffected, r.Failed())
+	}
+
+	var count, nulls int
+	err = db.QueryRow(fmt.Sprintf("select count(*), sum(b is null) from %s", *table)).Scan(&count, &nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != n || nulls != 1 {
+		t.Errorf("got %d rows with %d nulls", count, nulls)
+	}
+}
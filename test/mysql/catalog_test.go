@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	_ "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestCatalog_TableColumnsKeys(t *testing.T) {
+	conn := fmt.Sprintf("DSN=%s;", *dsn)
+
+	db, err := sql.Open("odbc", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	_, err = db.Exec(fmt.Sprintf("create table %s (id int primary key, name varchar(20) not null, memo text, index idx_name (name))", *table))
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := odbc.Connect(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	tables, err := c.Tables("", "", *table, "TABLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 || !strings.EqualFold(tables[0].Name, *table) {
+		t.Fatalf("tables: got %+v", tables)
+	}
+
+	columns, err := c.Columns("", "", *table, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, col := range columns {
+		names = append(names, col.Name)
+	}
+	if strings.Join(names, ",") != "id,name,memo" {
+		t.Errorf("columns: got %v", names)
+	}
+	if columns[1].ColumnSize != 20 || columns[1].Nullable != 0 || columns[1].OrdinalPosition != 2 {
+		t.Errorf("column name: got %+v", columns[1])
+	}
+
+	keys, err := c.PrimaryKeys("", "", *table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].Column != "id" || keys[0].KeySeq != 1 {
+		t.Errorf("primary keys: got %+v", keys)
+	}
+
+	indexes, err := c.Statistics("", "", *table, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, ix := range indexes {
+		if ix.IndexName == "idx_name" && ix.Column == "name" && ix.NonUnique {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("statistics: idx_name not found in %+v", indexes)
+	}
+}
@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	odbcdriver "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestConnector_Config(t *testing.T) {
+	connected := 0
+	db := sql.OpenDB(odbcdriver.NewConnector(odbcdriver.Config{
+		DSN:           fmt.Sprintf("DSN=%s;", *dsn),
+		Isolation:     sql.LevelSerializable,
+		StmtCacheSize: 4,
+		OnConnect: func(ctx context.Context, c *odbc.Connection) error {
+			connected++
+			return nil
+		},
+	}))
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	for i := 0; i < 3; i++ {
+		var n int
+		if err := db.QueryRow("select ?", i).Scan(&n); err != nil || n != i {
+			t.Fatalf("got %d, %v", n, err)
+		}
+	}
+	if connected != 1 {
+		t.Errorf("OnConnect called %d times", connected)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	var level string
+	if err := tx.QueryRow("select @@transaction_isolation").Scan(&level); err != nil {
+		t.Fatal(err)
+	}
+	if level != "SERIALIZABLE" {
+		t.Errorf("isolation level: got %s", level)
+	}
+}
+
+func TestOpen_ConfigKeys(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;go_query_timeout=1s;go_stmt_cache_size=2", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,26 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/jooita/sql/odbc"
+)
+
+func TestConnection_ConnString(t *testing.T) {
+	cs := &odbc.ConnString{}
+	cs.Set("DSN", *dsn)
+	c, err := odbc.Connect(cs.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	completed, err := c.ConnString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := completed.Get("dsn"); !ok || v != *dsn {
+		t.Errorf("completed connection string %s: DSN is %q", completed, v)
+	}
+	t.Log(completed)
+}
@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jooita/sql/odbc"
+)
+
+func TestError_DiagFields(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if s, err := c.ExecDirect(fmt.Sprintf("drop table %s", *table)); err == nil {
+		s.Close()
+	}
+	s, err := c.ExecDirect(fmt.Sprintf("create table %s (a int primary key)", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	stmt, err := c.Prepare(fmt.Sprintf("insert into %s values (?)", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	r, err := stmt.ExecuteBatch([][]interface{}{{1, 2, 2, 3}})
+
+	// drivers either fail the whole call or report the failed row
+	// with SQL_SUCCESS_WITH_INFO.
+	var diag []odbc.DiagRecord
+	var e *odbc.Error
+	if errors.As(err, &e) {
+		if !errors.Is(err, odbc.ErrConstraintViolation) {
+			t.Errorf("expected a constraint violation: %v", err)
+		}
+		diag = e.Diag
+	} else if err != nil {
+		t.Fatal(err)
+	} else {
+		if len(r.Failed()) == 0 {
+			t.Fatal("expected a failed row")
+		}
+		diag = stmt.Warnings()
+	}
+	found := false
+	for _, r := range diag {
+		t.Logf("%s row %d column %d origin %q/%q function %q server %q",
+			r.State, r.RowNumber, r.ColumnNumber, r.ClassOrigin, r.SubclassOrigin, r.DynamicFunction, r.ServerName)
+		if strings.HasPrefix(r.State, "23") {
+			found = true
+			// the second 2 is the third row of the parameter array.
+			if r.RowNumber != 3 {
+				t.Errorf("duplicate key: got row %d, want 3", r.RowNumber)
+			}
+		}
+	}
+	if !found {
+		t.Error("no diagnostic record for the duplicate key")
+	}
+}
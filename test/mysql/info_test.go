@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestInfo_Capabilities(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	name, err := c.Info(api.SQL_DBMS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "MySQL" {
+		t.Errorf("SQL_DBMS_NAME: got %q", name)
+	}
+	quote, err := c.InfoString(api.SQL_IDENTIFIER_QUOTE_CHAR)
+	if err != nil || quote != "`" {
+		t.Errorf("SQL_IDENTIFIER_QUOTE_CHAR: got %q, %v", quote, err)
+	}
+	if n, err := c.InfoUint16(api.SQL_MAX_COLUMN_NAME_LEN); err != nil || n == 0 {
+		t.Errorf("SQL_MAX_COLUMN_NAME_LEN: got %d, %v", n, err)
+	}
+	scroll, err := c.InfoBitmask(api.SQL_SCROLL_OPTIONS)
+	if err != nil || !scroll.Has(api.SQL_SO_FORWARD_ONLY) {
+		t.Errorf("SQL_SCROLL_OPTIONS: got %#x, %v", scroll, err)
+	}
+
+	if ok, err := c.SupportsFunction(api.SQL_API_SQLTABLES); err != nil || !ok {
+		t.Errorf("SQLTables: got %v, %v", ok, err)
+	}
+}
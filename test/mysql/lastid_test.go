@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func TestExec_LastInsertId(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;go_last_insert_id=true", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	_, err = db.Exec(fmt.Sprintf("create table %s (id int auto_increment primary key, b varchar(20))", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for want := int64(1); want <= 3; want++ {
+		r, err := db.Exec(fmt.Sprintf("insert into %s (b) values (?)", *table), "x")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id, err := r.LastInsertId(); err != nil || id != want {
+			t.Errorf("got %d, %v, want %d", id, err, want)
+		}
+	}
+
+	r, err := db.Exec(fmt.Sprintf("update %s set b = 'y'", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.LastInsertId(); err == nil {
+		t.Error("expected an error for an update")
+	}
+
+	// without go_last_insert_id no query follows the insert.
+	plain, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	r, err = plain.Exec(fmt.Sprintf("insert into %s (b) values (?)", *table), "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.LastInsertId(); err == nil {
+		t.Error("expected an error without go_last_insert_id")
+	}
+}
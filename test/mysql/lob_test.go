@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"fmt"
+	"hash"
+	"io"
+	"testing"
+
+	odbcdriver "github.com/jooita/sql/driver"
+)
+
+type digest struct {
+	h hash.Hash
+	n int64
+}
+
+func (d *digest) Scan(src interface{}) error {
+	r, ok := src.(io.Reader)
+	if !ok {
+		return fmt.Errorf("expected io.Reader, got %T", src)
+	}
+	d.h = sha1.New()
+	var err error
+	d.n, err = io.Copy(d.h, r)
+	return err
+}
+
+func TestSelect_LOBReader(t *testing.T) {
+	conn := fmt.Sprintf("DSN=%s;", *dsn)
+
+	db, err := sql.Open("odbc", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	_, err = db.Exec(fmt.Sprintf("create table %s (id int, a longblob, b longtext)", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := bytes.Repeat([]byte{9, 8, 7}, 300000)
+	text := bytes.Repeat([]byte("한글 text "), 50000)
+	_, err = db.Exec(fmt.Sprintf("insert into %s values (?, ?, ?)", *table), 1, blob, string(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := odbcdriver.WithLOBReaders(context.Background())
+	var id int
+	var a, b digest
+	err = db.QueryRowContext(ctx, fmt.Sprintf("select id, a, b from %s", *table)).Scan(&id, &a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha1.Sum(blob); a.n != int64(len(blob)) || !bytes.Equal(a.h.Sum(nil), sum[:]) {
+		t.Errorf("blob mismatch: read %d bytes, want %d", a.n, len(blob))
+	}
+	if sum := sha1.Sum(text); b.n != int64(len(text)) || !bytes.Equal(b.h.Sum(nil), sum[:]) {
+		t.Errorf("text mismatch: read %d bytes, want %d", b.n, len(text))
+	}
+}
@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func TestQuery_NamedParams(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var a, b, sum int
+	err = db.QueryRow("select :a, @b, :a + @b", sql.Named("a", 1), sql.Named("b", 2)).Scan(&a, &b, &sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != 1 || b != 2 || sum != 3 {
+		t.Errorf("got %d, %d, %d", a, b, sum)
+	}
+
+	if err := db.QueryRow("select :a", sql.Named("b", 2)).Scan(&a); err == nil {
+		t.Error("expected an error for a missing argument")
+	}
+
+	// without sql.Named arguments, @name is a user variable.
+	c, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.ExecContext(context.Background(), "set @x = 5"); err != nil {
+		t.Fatal(err)
+	}
+	var x int
+	if err := c.QueryRowContext(context.Background(), "select @x").Scan(&x); err != nil || x != 5 {
+		t.Errorf("user variable: got %d, %v", x, err)
+	}
+}
+
+func TestPrepare_NamedParams(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// prepared without go_named_params, the placeholders are rewritten
+	// on the first execution with sql.Named arguments.
+	stmt, err := db.Prepare("select :a + @b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	for i := 0; i < 2; i++ {
+		var sum int
+		if err := stmt.QueryRow(sql.Named("a", i), sql.Named("b", 2)).Scan(&sum); err != nil {
+			t.Fatal(err)
+		}
+		if sum != i+2 {
+			t.Errorf("got %d, want %d", sum, i+2)
+		}
+	}
+	if _, err := stmt.Exec(sql.Named("a", 1)); err == nil {
+		t.Error("expected an error for a missing argument")
+	}
+}
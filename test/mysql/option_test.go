@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestConnect_Options(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn),
+		odbc.LoginTimeout(5*time.Second),
+		odbc.Catalog("information_schema"),
+		odbc.Isolation(api.SQL_TXN_SERIALIZABLE),
+		odbc.AutoCommitMode(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	stmt, err := c.ExecDirect("select database()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := stmt.FetchOne()
+	stmt.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db := row.GetString(0); db != "information_schema" {
+		t.Errorf("current catalog: got %q", db)
+	}
+
+	level, err := c.IsolationLevel()
+	if err != nil || level != api.SQL_TXN_SERIALIZABLE {
+		t.Errorf("isolation level: got %d, %v", level, err)
+	}
+
+	if _, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn), "bogus"); err == nil {
+		t.Error("expected an error for an unsupported parameter")
+	}
+}
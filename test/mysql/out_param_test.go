@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/jooita/sql/driver"
+)
+
+func TestProcedure_OutParam(t *testing.T) {
+	conn := fmt.Sprintf("DSN=%s;", *dsn)
+
+	db, err := sql.Open("odbc", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	proc := *table + "_double"
+	db.Exec(fmt.Sprintf("drop procedure %s", proc))
+	_, err = db.Exec(fmt.Sprintf("create procedure %s (in a int, out b int, inout c varchar(20)) begin set b = a * 2; set c = concat(c, '!'); end", proc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Exec(fmt.Sprintf("drop procedure %s", proc))
+
+	var b int
+	c := "hello"
+	_, err = db.Exec(fmt.Sprintf("{call %s(?, ?, ?)}", proc), 21, sql.Out{Dest: &b}, sql.Out{Dest: &c, In: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b != 42 {
+		t.Errorf("out parameter: got %d, want 42", b)
+	}
+	if c != "hello!" {
+		t.Errorf("inout parameter: got %q, want %q", c, "hello!")
+	}
+}
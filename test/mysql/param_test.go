@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	_ "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestInsert_ParamTypes(t *testing.T) {
+	conn := fmt.Sprintf("DSN=%s;", *dsn)
+
+	db, err := sql.Open("odbc", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	_, err = db.Exec(fmt.Sprintf("create table %s (a int, b bigint unsigned, c varchar(20), d varbinary(20), e datetime(6))", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2018, 3, 3, 15, 4, 5, 123456000, time.UTC)
+	_, err = db.Exec(fmt.Sprintf("insert into %s values (?, ?, ?, ?, ?)", *table), int(7), uint64(1<<40), "한글", []byte{0, 1, 2}, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a int
+	var b int64
+	var c string
+	var d []byte
+	var e time.Time
+	err = db.QueryRow(fmt.Sprintf("select a, b, c, d, e from %s", *table)).Scan(&a, &b, &c, &d, &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != 7 || b != 1<<40 || c != "한글" || !bytes.Equal(d, []byte{0, 1, 2}) || !e.Equal(ts) {
+		t.Errorf("got (%v, %v, %q, %v, %v)", a, b, c, d, e)
+	}
+}
+
+func TestExecute_ParamCount(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	stmt, err := c.Prepare("select ?, ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	if err := stmt.Execute(1); err == nil {
+		t.Error("expected an error for too few parameters")
+	}
+	if err := stmt.Execute(1, 2, 3); err == nil {
+		t.Error("expected an error for too many parameters")
+	}
+	if err := stmt.Execute(1, 2); err != nil {
+		t.Error(err)
+	}
+}
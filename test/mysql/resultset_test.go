@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/jooita/sql/driver"
+)
+
+func TestQuery_NextResultSet(t *testing.T) {
+	// MULTI_STATEMENTS lets MySQL Connector/ODBC run a batch of statements.
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;MULTI_STATEMENTS=1;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	if _, err := db.Exec(fmt.Sprintf("create table %s (a int)", *table)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the row counts of the inserts and the update come before,
+	// between and after the result sets.
+	rows, err := db.Query(fmt.Sprintf(`insert into %[1]s values (1);
+select 10;
+insert into %[1]s values (2);
+insert into %[1]s values (3);
+select a from %[1]s order by a;
+update %[1]s set a = a + 1;
+select count(*) from %[1]s`, *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var sets [][]int
+	for {
+		var set []int
+		for rows.Next() {
+			var a int
+			if err := rows.Scan(&a); err != nil {
+				t.Fatal(err)
+			}
+			set = append(set, a)
+		}
+		sets = append(sets, set)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(sets), "[[10] [1 2 3] [3]]"; got != want {
+		t.Errorf("got result sets %s, want %s", got, want)
+	}
+}
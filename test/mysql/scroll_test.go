@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jooita/sql/api"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestStatement_Scroll(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	query := "select 1 union all select 2 union all select 3 union all select 4"
+	stmt, err := c.Prepare(query, odbc.ScrollableCursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	if err := stmt.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(name string, want int64) func(*odbc.Row, error) {
+		return func(row *odbc.Row, err error) {
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if row == nil {
+				t.Fatalf("%s: no row", name)
+			}
+			if got := row.GetInt(0); got != want {
+				t.Errorf("%s: got %d, want %d", name, got, want)
+			}
+		}
+	}
+	check("Last", 4)(stmt.Last())
+	check("Prior", 3)(stmt.Prior())
+	check("First", 1)(stmt.First())
+	check("Absolute", 3)(stmt.Absolute(3))
+	check("Relative", 2)(stmt.Relative(-1))
+	check("Absolute", 4)(stmt.Absolute(-1))
+	check("First", 1)(stmt.First())
+	check("FetchOne", 2)(stmt.FetchOne())
+
+	row, err := stmt.Absolute(5)
+	if err != nil || row != nil {
+		t.Errorf("past the end: got %v, %v", row, err)
+	}
+
+	plain, err := c.Prepare(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	if err := plain.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.Last(); err != odbc.ErrNotScrollable {
+		t.Errorf("expected ErrNotScrollable, got %v", err)
+	}
+}
+
+func TestStatement_CursorOption(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	stmt, err := c.Prepare("select 1", odbc.Cursor(int(api.SQL_CURSOR_STATIC), int(api.SQL_CONCUR_READ_ONLY)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	if ct, err := stmt.CursorType(); err != nil || ct == int(api.SQL_CURSOR_FORWARD_ONLY) {
+		t.Errorf("cursor type: got %d, %v", ct, err)
+	}
+}
@@ -0,0 +1,63 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	odbcdriver "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestConn_PingAndResetSession(t *testing.T) {
+	db, err := sql.Open("odbc", fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	var autocommit int
+	if err := db.QueryRow("select @@autocommit").Scan(&autocommit); err != nil {
+		t.Fatal(err)
+	}
+	if autocommit != 1 {
+		t.Error("the transaction did not restore autocommit")
+	}
+}
+
+func TestConn_ConfiguredAutoCommit(t *testing.T) {
+	db := sql.OpenDB(odbcdriver.NewConnector(odbcdriver.Config{
+		DSN:     fmt.Sprintf("DSN=%s;", *dsn),
+		Options: []odbc.Option{odbc.AutoCommitMode(false)},
+	}))
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	// neither the end of the transaction nor ResetSession turn it on.
+	var autocommit int
+	if err := db.QueryRow("select @@autocommit").Scan(&autocommit); err != nil {
+		t.Fatal(err)
+	}
+	if autocommit != 0 {
+		t.Error("autocommit was turned on")
+	}
+}
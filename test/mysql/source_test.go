@@ -0,0 +1,37 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/jooita/sql/odbc"
+)
+
+func TestDataSources(t *testing.T) {
+	sources, err := odbc.DataSources(odbc.AllDataSources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var driver string
+	for _, s := range sources {
+		if s.Name == *dsn {
+			driver = s.Description
+		}
+	}
+	if driver == "" {
+		t.Fatalf("data source %q not found in %v", *dsn, sources)
+	}
+
+	drivers, err := odbc.Drivers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range drivers {
+		if d.Name == driver {
+			if d.Attributes["Driver"] == "" {
+				t.Errorf("driver %q has no Driver attribute: %v", d.Name, d.Attributes)
+			}
+			return
+		}
+	}
+	t.Errorf("driver %q not found in %v", driver, drivers)
+}
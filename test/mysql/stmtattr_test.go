@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jooita/sql/api"
+	odbcdriver "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestStatement_Attributes(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	stmt, err := c.Prepare("select 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	if err := stmt.SetQueryTimeout(1500 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := stmt.QueryTimeout(); err != nil || d != 2*time.Second {
+		t.Errorf("query timeout: got %v, %v", d, err)
+	}
+	if err := stmt.SetMaxRows(10); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := stmt.MaxRows(); err != nil || n != 10 {
+		t.Errorf("max rows: got %d, %v", n, err)
+	}
+	// the cursor type can only change before the statement is prepared.
+	if ct, err := stmt.CursorType(); err != nil || ct != int(api.SQL_CURSOR_FORWARD_ONLY) {
+		t.Errorf("cursor type: got %d, %v", ct, err)
+	}
+	if err := stmt.SetNoScan(true); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := stmt.NoScan(); err != nil || !b {
+		t.Errorf("noscan: got %v, %v", b, err)
+	}
+}
+
+func TestConnector_QueryTimeout(t *testing.T) {
+	db := sql.OpenDB(odbcdriver.NewConnector(odbcdriver.Config{
+		DSN:          fmt.Sprintf("DSN=%s;", *dsn),
+		QueryTimeout: time.Second,
+	}))
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRow("select sleep(3)").Scan(&n); err == nil {
+		t.Errorf("expected a query timeout, got %d", n)
+	}
+}
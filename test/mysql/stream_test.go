@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	_ "github.com/jooita/sql/driver"
+	"github.com/jooita/sql/odbc"
+)
+
+func TestInsert_StreamParam(t *testing.T) {
+	conn := fmt.Sprintf("DSN=%s;", *dsn)
+
+	db, err := sql.Open("odbc", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec(fmt.Sprintf("drop table %s", *table))
+	_, err = db.Exec(fmt.Sprintf("create table %s (a longblob, b longtext)", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := bytes.Repeat([]byte{0, 1, 2, 3}, 100000)
+	text := strings.Repeat("가나다abc", 30000)
+	_, err = db.Exec(fmt.Sprintf("insert into %s values (?, ?)", *table),
+		odbc.Stream{R: bytes.NewReader(blob), Length: int64(len(blob))},
+		odbc.Stream{R: strings.NewReader(text), Length: -1, Text: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a []byte
+	var b string
+	err = db.QueryRow(fmt.Sprintf("select a, b from %s", *table)).Scan(&a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a, blob) {
+		t.Errorf("blob mismatch: got %d bytes, want %d", len(a), len(blob))
+	}
+	if b != text {
+		t.Errorf("text mismatch: got %d bytes, want %d", len(b), len(text))
+	}
+}
+
+func TestExecute_StreamConsumed(t *testing.T) {
+	c, err := odbc.Connect(fmt.Sprintf("DSN=%s;", *dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if s, err := c.ExecDirect(fmt.Sprintf("drop table %s", *table)); err == nil {
+		s.Close()
+	}
+	s, err := c.ExecDirect(fmt.Sprintf("create table %s (a longblob)", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	stmt, err := c.Prepare(fmt.Sprintf("insert into %s values (?)", *table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	blob := []byte("abc")
+	if err := stmt.Execute(odbc.Stream{R: bytes.NewReader(blob), Length: int64(len(blob))}); err != nil {
+		t.Fatal(err)
+	}
+	// without new parameters the exhausted reader is still bound.
+	if err := stmt.Execute(); err == nil {
+		t.Error("expected an error executing a consumed stream again")
+	}
+	if err := stmt.Execute(odbc.Stream{R: bytes.NewReader(blob), Length: int64(len(blob))}); err != nil {
+		t.Errorf("rebound stream: %v", err)
+	}
+}